@@ -0,0 +1,139 @@
+// Package logrusadapter redirects the output of logrus loggers into
+// gologger, helping codebases migrate incrementally without mixed output
+// styles.
+package logrusadapter
+
+import (
+	"io"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/sirupsen/logrus"
+)
+
+// Hook is a logrus hook logging the entries with a gologger logger
+type Hook struct {
+	logger *gologger.Logger
+}
+
+var _ logrus.Hook = &Hook{}
+
+// NewHook returns a hook logging the entries with the logger,
+// DefaultLogger being used if nil
+func NewHook(logger *gologger.Logger) *Hook {
+	if logger == nil {
+		logger = gologger.DefaultLogger
+	}
+	return &Hook{logger: logger}
+}
+
+// Redirect makes the logrus logger write its entries through the gologger
+// logger only: the hook is added and the logrus output discarded. The level
+// of the logrus logger is set to trace so that the gologger level applies.
+func Redirect(from *logrus.Logger, to *gologger.Logger) {
+	from.AddHook(NewHook(to))
+	from.SetOutput(io.Discard)
+	from.SetLevel(logrus.TraceLevel)
+}
+
+// Levels returns all the logrus levels
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire logs the entry. Panic and fatal entries are logged at the error
+// level with the FTL label, logrus taking care of panicking or exiting.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	level := Level(entry.Level)
+	if !h.logger.Enabled(level) {
+		return nil
+	}
+	event := h.logger.WithLevel(level)
+	if entry.Level <= logrus.FatalLevel {
+		event.Label("FTL")
+	}
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok && key == logrus.ErrorKey {
+			event.Err(err)
+			continue
+		}
+		event.Any(key, value)
+	}
+	event.Msg(entry.Message)
+	return nil
+}
+
+// Level maps a logrus level to a gologger level
+func Level(level logrus.Level) levels.Level {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel:
+		return levels.LevelError
+	case logrus.WarnLevel:
+		return levels.LevelWarning
+	case logrus.InfoLevel:
+		return levels.LevelInfo
+	case logrus.DebugLevel:
+		return levels.LevelDebug
+	default:
+		return levels.LevelTrace
+	}
+}
+
+// Formatter is a logrus formatter rendering the entries with a gologger
+// formatter, for logrus loggers which keep their own output
+type Formatter struct {
+	// Formatter is the gologger formatter, a CLI formatter by default
+	Formatter formatter.Formatter
+}
+
+var _ logrus.Formatter = &Formatter{}
+
+// NewFormatter returns a logrus formatter rendering the entries with the
+// gologger formatter, a CLI formatter being used if nil
+func NewFormatter(f formatter.Formatter) *Formatter {
+	if f == nil {
+		f = formatter.NewCLI(false)
+	}
+	return &Formatter{Formatter: f}
+}
+
+// Format renders the entry followed by a new line
+func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	level := Level(entry.Level)
+	metadata := make(map[string]interface{}, len(entry.Data)+1)
+	for key, value := range entry.Data {
+		metadata[key] = value
+	}
+	if label := levelLabel(entry.Level); label != "" {
+		metadata["label"] = label
+	}
+	data, err := f.Formatter.Format(&formatter.LogEvent{
+		Message:  entry.Message,
+		Level:    level,
+		Metadata: metadata,
+		Time:     entry.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// levelLabel returns the default gologger label of the logrus level
+func levelLabel(level logrus.Level) string {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return "FTL"
+	case logrus.ErrorLevel:
+		return "ERR"
+	case logrus.WarnLevel:
+		return "WRN"
+	case logrus.InfoLevel:
+		return "INF"
+	case logrus.DebugLevel:
+		return "DBG"
+	default:
+		return "TRC"
+	}
+}
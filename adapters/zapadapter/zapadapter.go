@@ -0,0 +1,94 @@
+// Package zapadapter provides a zapcore.Core writing the zap entries
+// through a gologger logger, helping codebases migrate incrementally
+// without mixed output styles.
+package zapadapter
+
+import (
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/gologger/levels"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core is a zapcore.Core logging the entries with a gologger logger. The
+// gologger level decides which entries are written.
+type Core struct {
+	logger *gologger.Logger
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = &Core{}
+
+// NewCore returns a core logging the entries with the logger,
+// DefaultLogger being used if nil. It is used with zap.New(core).
+func NewCore(logger *gologger.Logger) *Core {
+	if logger == nil {
+		logger = gologger.DefaultLogger
+	}
+	return &Core{logger: logger}
+}
+
+// Enabled reports whether the gologger logger writes the level
+func (c *Core) Enabled(level zapcore.Level) bool {
+	return c.logger.Enabled(Level(level))
+}
+
+// With returns a core adding the fields to the entries
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(clone.fields, c.fields...)
+	clone.fields = append(clone.fields, fields...)
+	return &clone
+}
+
+// Check adds the core to the checked entry if the level is enabled
+func (c *Core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write logs the entry with the fields. Panic and fatal entries are logged
+// at the error level with the FTL label, zap taking care of panicking or
+// exiting.
+func (c *Core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(encoder)
+	}
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	event := c.logger.WithLevel(Level(entry.Level))
+	if entry.Level >= zapcore.DPanicLevel {
+		event.Label("FTL")
+	}
+	if entry.LoggerName != "" {
+		event.Str("logger", entry.LoggerName)
+	}
+	event.Fields(encoder.Fields)
+	event.Msg(entry.Message)
+	return nil
+}
+
+// Sync waits for the events to be written by the logger writers
+func (c *Core) Sync() error {
+	c.logger.Barrier()
+	return nil
+}
+
+// Level maps a zap level to a gologger level
+func Level(level zapcore.Level) levels.Level {
+	switch {
+	case level >= zapcore.ErrorLevel:
+		return levels.LevelError
+	case level >= zapcore.WarnLevel:
+		return levels.LevelWarning
+	case level >= zapcore.InfoLevel:
+		return levels.LevelInfo
+	default:
+		return levels.LevelDebug
+	}
+}
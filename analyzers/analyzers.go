@@ -0,0 +1,105 @@
+// Package analyzers provides static analyzers for code using gologger.
+package analyzers
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const gologgerPath = "github.com/projectdiscovery/gologger"
+
+// DanglingEvent reports event chains which are never emitted because the
+// final Msg, Msgf or MsgFunc call is missing, e.g. gologger.Info().Str("k", "v").
+// Only the chains starting with an event constructor such as Info or
+// Logger.Error are reported, events stored in variables or passed to
+// callbacks being emitted elsewhere.
+var DanglingEvent = &analysis.Analyzer{
+	Name:     "danglingevent",
+	Doc:      "report gologger event chains missing a Msg, Msgf or MsgFunc call",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDanglingEvent,
+}
+
+func runDanglingEvent(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.ExprStmt)(nil)}
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		stmt := node.(*ast.ExprStmt)
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok || !isEvent(pass.TypesInfo.TypeOf(call)) {
+			return
+		}
+		// an expression statement discarding an *Event created by the chain
+		// means the event is never emitted
+		if isEventConstructor(pass, chainRoot(pass, call)) {
+			pass.Reportf(call.Pos(), "gologger event is never emitted, missing Msg, Msgf or MsgFunc call")
+		}
+	})
+	return nil, nil
+}
+
+// chainRoot returns the first call of a chain of *Event method calls, e.g.
+// gologger.Info() for gologger.Info().Str("k", "v").Int("n", 1)
+func chainRoot(pass *analysis.Pass, call *ast.CallExpr) *ast.CallExpr {
+	for {
+		selector, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return call
+		}
+		receiver, ok := astutil.Unparen(selector.X).(*ast.CallExpr)
+		if !ok || !isEvent(pass.TypesInfo.TypeOf(receiver)) {
+			return call
+		}
+		call = receiver
+	}
+}
+
+// isEventConstructor reports whether the call creates a new event: a
+// gologger function or a *gologger.Logger method returning an *Event
+func isEventConstructor(pass *analysis.Pass, call *ast.CallExpr) bool {
+	var ident *ast.Ident
+	switch fun := astutil.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return false
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != gologgerPath {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv == nil || isLogger(recv.Type())
+}
+
+// isEvent reports whether t is *gologger.Event
+func isEvent(t types.Type) bool {
+	return isGologgerPointer(t, "Event")
+}
+
+// isLogger reports whether t is *gologger.Logger
+func isLogger(t types.Type) bool {
+	return isGologgerPointer(t, "Logger")
+}
+
+// isGologgerPointer reports whether t is a pointer to the named gologger type
+func isGologgerPointer(t types.Type, name string) bool {
+	pointer, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := pointer.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == name && obj.Pkg() != nil && obj.Pkg().Path() == gologgerPath
+}
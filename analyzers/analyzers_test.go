@@ -0,0 +1,11 @@
+package analyzers
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDanglingEvent(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), DanglingEvent, "a")
+}
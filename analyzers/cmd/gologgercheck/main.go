@@ -0,0 +1,15 @@
+// gologgercheck reports gologger events which are never emitted.
+//
+// Usage:
+//
+//	go run github.com/projectdiscovery/gologger/analyzers/cmd/gologgercheck ./...
+package main
+
+import (
+	"github.com/projectdiscovery/gologger/analyzers"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzers.DanglingEvent)
+}
@@ -0,0 +1,25 @@
+package a
+
+import "github.com/projectdiscovery/gologger"
+
+func dangling(l *gologger.Logger) {
+	gologger.Info()                            // want "gologger event is never emitted"
+	gologger.Info().Str("k", "v")              // want "gologger event is never emitted"
+	l.Error().Str("k", "v")                    // want "gologger event is never emitted"
+	gologger.Named("dns").Info().Str("k", "v") // want "gologger event is never emitted"
+	(l.Info()).Str("k", "v")                   // want "gologger event is never emitted"
+}
+
+func emitted(l *gologger.Logger) {
+	gologger.Info().Str("k", "v").Msg("done")
+	l.Error().Msg("failed")
+
+	ev := l.Info()
+	ev.Str("k", "v")
+	ev.Msg("done")
+
+	gologger.Each(l, []string{"a"}, func(e *gologger.Event, item string) {
+		e.Str("item", item)
+		e.Msg("item")
+	})
+}
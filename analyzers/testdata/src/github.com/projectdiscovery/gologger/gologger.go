@@ -0,0 +1,17 @@
+// Package gologger is a stub of the gologger API used by the analyzer tests
+package gologger
+
+type Logger struct{}
+
+type Event struct{}
+
+var DefaultLogger = &Logger{}
+
+func Info() *Event                      { return DefaultLogger.Info() }
+func (l *Logger) Info() *Event          { return &Event{} }
+func (l *Logger) Error() *Event         { return &Event{} }
+func Named(name string) *Logger         { return &Logger{} }
+func (e *Event) Str(k, v string) *Event { return e }
+func (e *Event) Msg(message string)     {}
+
+func Each(l *Logger, items []string, fn func(*Event, string)) {}
@@ -0,0 +1,79 @@
+package benchmarks
+
+import (
+	"io"
+	"testing"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// discardWriter drops the formatted events
+type discardWriter struct{}
+
+func (discardWriter) Write(data []byte, level levels.Level) {}
+
+func newGologger() *gologger.Logger {
+	l := &gologger.Logger{}
+	l.SetFormatter(&formatter.JSON{})
+	l.SetWriter(discardWriter{})
+	l.SetMaxLevel(levels.LevelInfo)
+	return l
+}
+
+func newZap() *zap.Logger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), zapcore.InfoLevel))
+}
+
+func BenchmarkGologgerJSON(b *testing.B) {
+	l := newGologger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
+
+func BenchmarkZapJSON(b *testing.B) {
+	l := newZap()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request completed", zap.String("host", "example.com"), zap.Int("port", 443))
+	}
+}
+
+func BenchmarkZerologJSON(b *testing.B) {
+	l := zerolog.New(io.Discard).With().Timestamp().Logger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
+
+func BenchmarkGologgerDisabled(b *testing.B) {
+	l := newGologger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
+
+func BenchmarkZapDisabled(b *testing.B) {
+	l := newZap()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("request completed", zap.String("host", "example.com"), zap.Int("port", 443))
+	}
+}
+
+func BenchmarkZerologDisabled(b *testing.B) {
+	l := zerolog.New(io.Discard).Level(zerolog.InfoLevel)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
@@ -0,0 +1,7 @@
+// Package benchmarks compares the performance of gologger with other
+// structured loggers. It is a separate module so that the compared loggers
+// aren't dependencies of gologger. The reference results are kept in
+// testdata/results.txt and can be refreshed with:
+//
+//	go test -run '^$' -bench . -benchmem > testdata/results.txt
+package benchmarks
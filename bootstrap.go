@@ -0,0 +1,105 @@
+package gologger
+
+import (
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// bootstrap holds the events logged before the logger configuration is final
+type bootstrap struct {
+	mutex     sync.Mutex
+	events    []*Event
+	maxEvents int
+	dropped   int
+}
+
+// Bootstrap starts buffering the events logged on the logger, up to
+// maxEvents, until EndBootstrap is called. Events logged while flags and
+// config files are parsed are then written with the final level, formatter
+// and writers instead of leaking in the default format.
+func (l *Logger) Bootstrap(maxEvents int) {
+	l.bootstrap.Store(&bootstrap{maxEvents: maxEvents})
+}
+
+// EndBootstrap stops buffering and replays the buffered events through the
+// current configuration. Fatal events end the bootstrap automatically.
+func (l *Logger) EndBootstrap() {
+	b := l.bootstrap.Swap(nil)
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	events, dropped := b.events, b.dropped
+	b.events = nil
+	b.mutex.Unlock()
+
+	for _, event := range events {
+		if l.timestamp && event.level.Rank() >= l.timestampMinLevel.Rank() {
+			if _, ok := event.metadata["timestamp"]; !ok {
+				event.TimeStamp()
+			}
+		}
+		for k, v := range l.fields {
+			if _, ok := event.metadata[k]; !ok {
+				event.metadata[k] = v
+			}
+		}
+		l.Log(event)
+	}
+	if dropped > 0 {
+		l.Warning().Int("dropped", dropped).Msg("bootstrap buffer full, events were dropped")
+	}
+}
+
+// Bootstrap starts buffering the events of the default logger
+func Bootstrap(maxEvents int) {
+	DefaultLogger.Bootstrap(maxEvents)
+}
+
+// EndBootstrap replays the buffered events of the default logger
+func EndBootstrap() {
+	DefaultLogger.EndBootstrap()
+}
+
+// buffer stores a copy of the event, dropping it once the buffer is full
+func (b *bootstrap) buffer(event *Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.events) >= b.maxEvents {
+		b.dropped++
+		return
+	}
+	// pooled events are reused so the event and its metadata are copied
+	buffered := &Event{
+		logger:    event.logger,
+		level:     event.level,
+		message:   event.message,
+		metadata:  make(map[string]interface{}, len(event.metadata)),
+		time:      event.time,
+		forced:    event.forced,
+		transient: event.transient,
+		lazy:      event.lazy,
+		verbosity: event.verbosity,
+		named:     event.named,
+	}
+	for k, v := range event.metadata {
+		buffered.metadata[k] = v
+	}
+	b.events = append(b.events, buffered)
+}
+
+// bootstrapping buffers the event if the logger is bootstrapping
+func (l *Logger) bootstrapping(event *Event) bool {
+	b := l.bootstrap.Load()
+	if b == nil {
+		return false
+	}
+	if event.level == levels.LevelFatal {
+		l.EndBootstrap()
+		return false
+	}
+	b.buffer(event)
+	return true
+}
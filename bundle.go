@@ -0,0 +1,135 @@
+package gologger
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// bundleConfig describes the logger configuration in a debug bundle
+type bundleConfig struct {
+	MaxLevel    string   `json:"max_level"`
+	Formatter   string   `json:"formatter"`
+	Writer      string   `json:"writer"`
+	Sinks       []string `json:"sinks,omitempty"`
+	Timestamp   bool     `json:"timestamp"`
+	CallerInfo  bool     `json:"caller_info"`
+	StackTraces bool     `json:"stack_traces"`
+	EventIDs    bool     `json:"event_ids"`
+	Hooks       int      `json:"hooks"`
+	FatalPolicy int      `json:"fatal_policy"`
+}
+
+// bundleEnvironment describes the detected environment in a debug bundle
+type bundleEnvironment struct {
+	Time      time.Time `json:"time"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	GoVersion string    `json:"go_version"`
+	// Program is the executable name, arguments are omitted as they may hold secrets
+	Program  string `json:"program"`
+	Terminal bool   `json:"terminal"`
+	Color    bool   `json:"color"`
+	Width    int    `json:"width"`
+}
+
+// ExportDebugBundle writes a zip file at path containing the recent events
+// kept by the ring buffer writers of the logger (events.log), its
+// configuration (config.json), stats (stats.json) and the detected
+// environment (environment.json), to be attached to bug reports.
+func (l *Logger) ExportDebugBundle(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	if err := l.writeDebugBundle(archive); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// ExportDebugBundle writes a debug bundle of the default logger at path
+func ExportDebugBundle(path string) error {
+	return DefaultLogger.ExportDebugBundle(path)
+}
+
+func (l *Logger) writeDebugBundle(archive *zip.Writer) error {
+	events, err := archive.Create("events.log")
+	if err != nil {
+		return err
+	}
+	f, w := l.output()
+	writers := []writer.Writer{w}
+	for _, sink := range l.loadSinks() {
+		writers = append(writers, sink.Writer)
+	}
+	for _, w := range writers {
+		ring, ok := w.(*writer.Ring)
+		if !ok {
+			continue
+		}
+		for _, entry := range ring.Entries() {
+			if _, err := fmt.Fprintf(events, "%s\n", entry.Data); err != nil {
+				return err
+			}
+		}
+	}
+
+	config := bundleConfig{
+		MaxLevel:    l.loadMaxLevel().String(),
+		Formatter:   fmt.Sprintf("%T", f),
+		Writer:      fmt.Sprintf("%T", w),
+		Timestamp:   l.timestamp,
+		CallerInfo:  l.callerInfo,
+		StackTraces: l.stackTraces,
+		EventIDs:    l.eventIDs,
+		Hooks:       len(l.hooks),
+		FatalPolicy: int(l.fatalPolicy),
+	}
+	for _, sink := range l.loadSinks() {
+		config.Sinks = append(config.Sinks, fmt.Sprintf("%T %T %s", l.sinkFormatter(sink), sink.Writer, sink.MaxLevel))
+	}
+	environment := bundleEnvironment{
+		Time:      time.Now(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		Program:   filepath.Base(os.Args[0]),
+		Terminal:  writer.IsTerminal(os.Stderr),
+		Color:     writer.SupportsColor(os.Stderr),
+		Width:     writer.TerminalWidth(),
+	}
+
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{"config.json", config},
+		{"stats.json", l.Stats()},
+		{"environment.json", environment},
+	}
+	for _, f := range files {
+		w, err := archive.Create(f.name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package gologger
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// certificate is the structured representation of a x509 certificate,
+// rendered as an object by the JSON formatter and as a compact summary by
+// text formatters
+type certificate struct {
+	Subject     string    `json:"subject"`
+	Issuer      string    `json:"issuer"`
+	DNSNames    []string  `json:"dns_names,omitempty"`
+	Serial      string    `json:"serial"`
+	NotBefore   time.Time `json:"not_before"`
+	NotAfter    time.Time `json:"not_after"`
+	Fingerprint string    `json:"fingerprint_sha256"`
+}
+
+// String returns a compact summary of the certificate
+func (c certificate) String() string {
+	return fmt.Sprintf("subject=%q issuer=%q not_after=%s sha256=%s",
+		c.Subject, c.Issuer, c.NotAfter.Format(time.RFC3339), c.Fingerprint)
+}
+
+// Cert adds the subject, issuer, names, serial, validity and SHA-256
+// fingerprint of the certificate as a metadata item. A nil certificate
+// is ignored.
+func (e *Event) Cert(key string, cert *x509.Certificate) *Event {
+	e.checkNotEmitted()
+	if cert == nil {
+		return e
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+	e.metadata[key] = certificate{
+		Subject:     cert.Subject.String(),
+		Issuer:      cert.Issuer.String(),
+		DNSNames:    cert.DNSNames,
+		Serial:      cert.SerialNumber.String(),
+		NotBefore:   cert.NotBefore.UTC(),
+		NotAfter:    cert.NotAfter.UTC(),
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}
+	return e
+}
@@ -0,0 +1,21 @@
+package gologger
+
+import "github.com/projectdiscovery/gologger/levels"
+
+// Checkpoint writes a progress marker labeled CHK with the checkpoint name
+// under the "checkpoint" key along with the given fields, so that resume
+// logic or humans can locate it quickly. Checkpoints bypass sampling and
+// are indexed by ring buffer writers, see writer.Ring.Checkpoints.
+func (l *Logger) Checkpoint(name string, fields map[string]interface{}) {
+	event := newEventWithLevelAndLogger(levels.LevelInfo, l).Label("CHK")
+	for k, v := range fields {
+		event.metadata[k] = v
+	}
+	event.metadata["checkpoint"] = name
+	event.Force().Msgf("checkpoint %s", name)
+}
+
+// Checkpoint writes a progress marker on the default logger
+func Checkpoint(name string, fields map[string]interface{}) {
+	DefaultLogger.Checkpoint(name, fields)
+}
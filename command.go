@@ -0,0 +1,34 @@
+package gologger
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// CaptureCommand logs the output of the command: each line written to its
+// stdout and stderr is logged at stdoutLevel and stderrLevel respectively,
+// with the cmd field set to the command name and the stream field to the
+// stream name. It must be called before the command is started, and the
+// returned function called once it has exited (after Wait) to log the last
+// lines which aren't newline terminated.
+func (l *Logger) CaptureCommand(cmd *exec.Cmd, stdoutLevel, stderrLevel levels.Level) (flush func()) {
+	name := filepath.Base(cmd.Path)
+	if len(cmd.Args) > 0 {
+		name = filepath.Base(cmd.Args[0])
+	}
+	stdout := &lineWriter{logger: l, level: stdoutLevel, fields: map[string]interface{}{"cmd": name, "stream": "stdout"}}
+	stderr := &lineWriter{logger: l, level: stderrLevel, fields: map[string]interface{}{"cmd": name, "stream": "stderr"}}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return func() {
+		_ = stdout.Close()
+		_ = stderr.Close()
+	}
+}
+
+// CaptureCommand logs the output of the command with the default logger
+func CaptureCommand(cmd *exec.Cmd, stdoutLevel, stderrLevel levels.Level) (flush func()) {
+	return DefaultLogger.CaptureCommand(cmd, stdoutLevel, stderrLevel)
+}
@@ -0,0 +1,309 @@
+package gologger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+	"gopkg.in/yaml.v3"
+)
+
+// Config describes a whole logging pipeline, allowing applications to
+// configure the logger from a YAML or JSON file instead of code
+type Config struct {
+	// Level is the max level of the logger, info by default
+	Level string `json:"level" yaml:"level"`
+	// Format is the default format of the outputs: cli (default), json,
+	// dev, plain, gelf, ecs, fixed, xml or audit
+	Format string `json:"format" yaml:"format"`
+	// Color is the color mode of the cli format: auto (default), always or never
+	Color string `json:"color" yaml:"color"`
+	// Timestamps adds a timestamp to every event
+	Timestamps bool `json:"timestamps" yaml:"timestamps"`
+	// Outputs are the destinations of the events, the first one is the
+	// logger writer and the others are sinks. Events go to stderr by default.
+	Outputs []OutputConfig `json:"outputs" yaml:"outputs"`
+	// Sampling configures a sampler per level name
+	Sampling map[string]SamplingConfig `json:"sampling" yaml:"sampling"`
+	// Redaction configures the redactors
+	Redaction RedactionConfig `json:"redaction" yaml:"redaction"`
+	// Filter is a filter expression, see ParseFilter
+	Filter string `json:"filter" yaml:"filter"`
+}
+
+// OutputConfig describes a destination of the events
+type OutputConfig struct {
+	// Type is one of stderr (default), stdout, file, syslog, network or gelf
+	Type string `json:"type" yaml:"type"`
+	// Level is the max level of the output, the logger level by default
+	Level string `json:"level" yaml:"level"`
+	// Format overrides the default format for the output
+	Format string `json:"format" yaml:"format"`
+	// Path is the log file path of file outputs
+	Path string `json:"path" yaml:"path"`
+	// Rotation configures the rotation of file outputs
+	Rotation RotationConfig `json:"rotation" yaml:"rotation"`
+	// MinFreeSpace is the free disk space in megabytes below which file
+	// outputs drop debug and verbose events
+	MinFreeSpace int `json:"min_free_space" yaml:"min_free_space"`
+	// Network and Address are the destination of syslog, network and gelf outputs
+	Network string `json:"network" yaml:"network"`
+	Address string `json:"address" yaml:"address"`
+	// Tag is the syslog tag
+	Tag string `json:"tag" yaml:"tag"`
+}
+
+// RotationConfig configures the rotation of file outputs
+type RotationConfig struct {
+	Enabled  bool          `json:"enabled" yaml:"enabled"`
+	MaxSize  int           `json:"max_size" yaml:"max_size"`
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	Hourly   bool          `json:"hourly" yaml:"hourly"`
+	Daily    bool          `json:"daily" yaml:"daily"`
+	Compress bool          `json:"compress" yaml:"compress"`
+	// CompressFormat is the compression format of rotated files (gz, zst or none)
+	CompressFormat string `json:"compress_format" yaml:"compress_format"`
+	// MaxBackups, MaxAge and MaxTotalSize (in megabytes) bound the retained rotated files
+	MaxBackups   int           `json:"max_backups" yaml:"max_backups"`
+	MaxAge       time.Duration `json:"max_age" yaml:"max_age"`
+	MaxTotalSize int           `json:"max_total_size" yaml:"max_total_size"`
+}
+
+// SamplingConfig keeps the first events and then one out of thereafter,
+// or events with the given probability when set
+type SamplingConfig struct {
+	First       uint64  `json:"first" yaml:"first"`
+	Thereafter  uint64  `json:"thereafter" yaml:"thereafter"`
+	Probability float64 `json:"probability" yaml:"probability"`
+}
+
+// RedactionConfig configures the redactors of the logger
+type RedactionConfig struct {
+	// Defaults enables the DefaultSecretPatterns
+	Defaults bool `json:"defaults" yaml:"defaults"`
+	// Patterns are additional regular expressions to mask
+	Patterns []string `json:"patterns" yaml:"patterns"`
+	// Keys are the metadata keys whose values are masked
+	Keys []string `json:"keys" yaml:"keys"`
+}
+
+// LoadConfig reads the configuration file at path, decoded as JSON for
+// .json files and as YAML otherwise
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, config)
+	} else {
+		err = yaml.Unmarshal(data, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// NewFromConfig returns a new logger configured from the configuration
+func NewFromConfig(config *Config) (*Logger, error) {
+	l := &Logger{}
+
+	maxLevel, err := parseConfigLevel(config.Level, levels.LevelInfo)
+	if err != nil {
+		return nil, err
+	}
+	l.SetMaxLevel(maxLevel)
+	if config.Timestamps {
+		l.SetTimestamp(true, levels.LevelFatal)
+	}
+
+	outputs := config.Outputs
+	if len(outputs) == 0 {
+		outputs = []OutputConfig{{Type: "stderr"}}
+	}
+	for i, output := range outputs {
+		w, err := newConfigWriter(output)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		format := output.Format
+		if format == "" {
+			format = config.Format
+		}
+		if format == "" && output.Type == "gelf" {
+			format = "gelf"
+		}
+		f, err := newConfigFormatter(format, config.Color)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		outputLevel, err := parseConfigLevel(output.Level, maxLevel)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		if i == 0 {
+			l.SetWriter(w)
+			l.SetFormatter(f)
+			l.SetMaxLevel(outputLevel)
+			continue
+		}
+		l.AddSink(NewSink(f, w, outputLevel))
+	}
+	if config.Color != "" && config.Color != "auto" {
+		// an explicit color mode takes precedence over the writer capabilities
+		mode, err := parseColorMode(config.Color)
+		if err != nil {
+			return nil, err
+		}
+		l.SetColorMode(mode)
+	}
+
+	for name, sampling := range config.Sampling {
+		level, err := levels.Parse(name)
+		if err != nil {
+			return nil, err
+		}
+		if sampling.Probability > 0 {
+			l.SetSampler(level, Random(sampling.Probability))
+			continue
+		}
+		l.SetSampler(level, FirstThenEvery(sampling.First, sampling.Thereafter))
+	}
+
+	if config.Filter != "" {
+		filter, err := ParseFilter(config.Filter)
+		if err != nil {
+			return nil, err
+		}
+		l.SetFilter(filter)
+	}
+
+	if config.Redaction.Defaults {
+		l.AddRedactor(PatternRedactor())
+	}
+	if len(config.Redaction.Patterns) > 0 {
+		patterns := make([]*regexp.Regexp, 0, len(config.Redaction.Patterns))
+		for _, pattern := range config.Redaction.Patterns {
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, err
+			}
+			patterns = append(patterns, compiled)
+		}
+		l.AddRedactor(PatternRedactor(patterns...))
+	}
+	if len(config.Redaction.Keys) > 0 {
+		l.AddRedactor(KeyRedactor(config.Redaction.Keys...))
+	}
+	return l, nil
+}
+
+func parseConfigLevel(name string, fallback levels.Level) (levels.Level, error) {
+	if name == "" {
+		return fallback, nil
+	}
+	return levels.Parse(name)
+}
+
+func parseColorMode(name string) (formatter.ColorMode, error) {
+	switch name {
+	case "", "auto":
+		return formatter.ColorAuto, nil
+	case "always":
+		return formatter.ColorAlways, nil
+	case "never":
+		return formatter.ColorNever, nil
+	default:
+		return 0, fmt.Errorf("invalid color mode %q", name)
+	}
+}
+
+func newConfigFormatter(format, color string) (formatter.Formatter, error) {
+	switch format {
+	case "", "cli":
+		mode, err := parseColorMode(color)
+		if err != nil {
+			return nil, err
+		}
+		return formatter.NewCLIWithColorMode(mode), nil
+	case "dev":
+		mode, err := parseColorMode(color)
+		if err != nil {
+			return nil, err
+		}
+		cli := formatter.NewCLIWithColorMode(mode)
+		cli.MultiLine = true
+		return cli, nil
+	case "json":
+		return &formatter.JSON{}, nil
+	case "plain":
+		return formatter.NewPlain(), nil
+	case "gelf":
+		return formatter.NewGELF(), nil
+	case "fixed":
+		return formatter.NewFixedWidth(), nil
+	case "xml":
+		return formatter.NewXML(), nil
+	case "ecs":
+		return formatter.NewECS(), nil
+	case "audit":
+		return formatter.NewAudit(), nil
+	default:
+		return nil, fmt.Errorf("invalid format %q", format)
+	}
+}
+
+func newConfigWriter(output OutputConfig) (writer.Writer, error) {
+	switch output.Type {
+	case "", "stderr":
+		return writer.NewCLI(), nil
+	case "stdout":
+		return writer.NewStdout(), nil
+	case "file":
+		if output.Path == "" {
+			return nil, fmt.Errorf("missing path for file output")
+		}
+		options := writer.DefaultFileWithRotationOptions
+		options.Location = filepath.Dir(output.Path)
+		options.FileName = filepath.Base(output.Path)
+		options.Rotate = output.Rotation.Enabled
+		options.MaxSize = output.Rotation.MaxSize
+		options.RotationInterval = output.Rotation.Interval
+		options.RotateEachHour = output.Rotation.Hourly
+		options.RotateEachDay = output.Rotation.Daily
+		options.Compress = output.Rotation.Compress
+		if output.Rotation.CompressFormat != "" {
+			options.ArchiveFormat = output.Rotation.CompressFormat
+		}
+		options.MaxBackups = output.Rotation.MaxBackups
+		options.MaxAge = output.Rotation.MaxAge
+		options.MaxTotalSize = output.Rotation.MaxTotalSize
+		options.MinFreeSpace = output.MinFreeSpace
+		return writer.NewFileWithRotation(&options)
+	case "syslog":
+		network := output.Network
+		if network == "" {
+			network = "udp"
+		}
+		return writer.NewSyslog(network, output.Address, writer.FacilityUser, output.Tag)
+	case "network":
+		network := output.Network
+		if network == "" {
+			network = "tcp"
+		}
+		return writer.NewNetwork(network, output.Address)
+	case "gelf":
+		return writer.NewGELF(output.Address)
+	default:
+		return nil, fmt.Errorf("invalid output type %q", output.Type)
+	}
+}
@@ -0,0 +1,97 @@
+package gologger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logger.yaml")
+	logPath := filepath.Join(dir, "scan.log")
+	config := `level: info
+format: json
+outputs:
+  - type: file
+    path: ` + logPath + `
+sampling:
+  info:
+    first: 1
+    thereafter: 0
+redaction:
+  keys: [token]
+`
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := NewFromConfig(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info().Str("token", "secret").Msg("first")
+	l.Info().Msg("sampled")
+	l.Debug().Msg("above the level")
+	l.Error().Msg("failed")
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d events %q, want 2", len(lines), lines)
+	}
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event["msg"] != "first" || event["level"] != "INF" || event["token"] != "[REDACTED]" {
+		t.Errorf("first event = %v", event)
+	}
+	if !strings.Contains(lines[1], `"msg":"failed"`) {
+		t.Errorf("second event = %s", lines[1])
+	}
+}
+
+func TestNewFromConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	for config, message := range map[string]string{
+		`{"level":"loud"}`:                         "loud",
+		`{"format":"csv"}`:                         `invalid format "csv"`,
+		`{"color":"sometimes"}`:                    `invalid color mode "sometimes"`,
+		`{"outputs":[{"type":"kafka"}]}`:           `output 0: invalid output type "kafka"`,
+		`{"outputs":[{"type":"file"}]}`:            "output 0: missing path for file output",
+		`{"sampling":{"noisy":{"first":1}}}`:       "noisy",
+		`{"redaction":{"patterns":["(unclosed"]}}`: "missing closing )",
+	} {
+		path := filepath.Join(dir, "logger.json")
+		if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewFromConfig(c); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("%s: error = %v, want %q", config, err, message)
+		}
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("no error for a missing file")
+	}
+	path := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(path, []byte("level: info"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "could not decode") {
+		t.Errorf("error = %v, want a decoding error", err)
+	}
+}
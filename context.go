@@ -0,0 +1,107 @@
+package gologger
+
+import (
+	"context"
+	"sync"
+)
+
+type loggerContextKey struct{}
+
+type fieldsContextKey struct{}
+
+var (
+	contextKeysMutex  sync.RWMutex
+	contextKeys       = make(map[string]interface{})
+	contextExtractors []ContextExtractor
+)
+
+// ContextExtractor extracts metadata items from a context, allowing the
+// identifiers of tracing systems to be propagated into the events
+type ContextExtractor interface {
+	// Extract returns the metadata items found in ctx
+	Extract(ctx context.Context) map[string]interface{}
+}
+
+// ContextExtractorFunc is an adapter to use functions as context extractors
+type ContextExtractorFunc func(ctx context.Context) map[string]interface{}
+
+// Extract returns f(ctx)
+func (f ContextExtractorFunc) Extract(ctx context.Context) map[string]interface{} {
+	return f(ctx)
+}
+
+// RegisterContextExtractor registers an extractor whose items are added by
+// Event.Ctx. The tracing module provides an extractor adding the trace_id
+// and span_id of OpenTelemetry spans.
+func RegisterContextExtractor(extractor ContextExtractor) {
+	contextKeysMutex.Lock()
+	defer contextKeysMutex.Unlock()
+
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// WithContext returns a copy of ctx carrying the logger
+func (l *Logger) WithContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx or the default logger
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerContextKey{}).(*Logger); ok && l != nil {
+		return l
+	}
+	return DefaultLogger
+}
+
+// ContextWithField returns a copy of ctx carrying a metadata item which is
+// added to events by Event.Ctx
+func ContextWithField(ctx context.Context, key string, value interface{}) context.Context {
+	parent, _ := ctx.Value(fieldsContextKey{}).(map[string]interface{})
+	fields := make(map[string]interface{}, len(parent)+1)
+	for k, v := range parent {
+		fields[k] = v
+	}
+	fields[key] = value
+	return context.WithValue(ctx, fieldsContextKey{}, fields)
+}
+
+// RegisterContextKey registers a context key whose value is added by
+// Event.Ctx under the given metadata name (e.g. a request id).
+func RegisterContextKey(name string, key interface{}) {
+	contextKeysMutex.Lock()
+	defer contextKeysMutex.Unlock()
+
+	contextKeys[name] = key
+}
+
+// Ctx adds the fields and label stored in ctx, the values of the registered
+// context keys and the items of the registered context extractors to the event
+func (e *Event) Ctx(ctx context.Context) *Event {
+	e.checkNotEmitted()
+	if ctx == nil {
+		return e
+	}
+	if fields, ok := ctx.Value(fieldsContextKey{}).(map[string]interface{}); ok {
+		for k, v := range fields {
+			e.metadata[k] = v
+		}
+	}
+	if label, ok := ctx.Value(labelContextKey{}).(string); ok {
+		e.overrideLabel(label)
+	}
+
+	contextKeysMutex.RLock()
+	defer contextKeysMutex.RUnlock()
+
+	for name, key := range contextKeys {
+		if value := ctx.Value(key); value != nil {
+			e.metadata[name] = value
+		}
+	}
+	for _, extractor := range contextExtractors {
+		for k, v := range extractor.Extract(ctx) {
+			e.metadata[k] = v
+		}
+	}
+	return e
+}
@@ -0,0 +1,74 @@
+package gologger
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// LevelHandler returns an http handler getting the max level of the logger
+// on GET and changing it on PUT, so that long running scans can be switched
+// to debug without restarting. The level is exchanged as {"level":"debug"},
+// PUT also accepts a plain level name.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			body, err := io.ReadAll(io.LimitReader(r.Body, 1024))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, err := parseLevelBody(body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			l.SetMaxLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Level levels.Level `json:"level"`
+		}{l.loadMaxLevel()})
+	})
+}
+
+// LevelHandler returns an http handler getting and changing the max level
+// of the default logger
+func LevelHandler() http.Handler {
+	return DefaultLogger.LevelHandler()
+}
+
+func parseLevelBody(body []byte) (levels.Level, error) {
+	var request struct {
+		Level levels.Level `json:"level"`
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "{") {
+		err := json.Unmarshal(body, &request)
+		return request.Level, err
+	}
+	return levels.Parse(string(body))
+}
+
+// raiseLevel increases the verbosity of the logger by one level up to trace
+func (l *Logger) raiseLevel() {
+	if level := l.loadMaxLevel(); level < levels.LevelTrace {
+		l.SetMaxLevel(level + 1)
+	}
+}
+
+// lowerLevel decreases the verbosity of the logger by one level down to
+// silent, which keeps printing results
+func (l *Logger) lowerLevel() {
+	if level := l.loadMaxLevel(); level > levels.LevelSilent {
+		l.SetMaxLevel(level - 1)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build !windows
+
+package gologger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// EnableLevelSignals increases the verbosity of the default logger by one
+// level on SIGUSR1 and decreases it on SIGUSR2. The returned function stops
+// handling the signals.
+func EnableLevelSignals() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == syscall.SIGUSR1 {
+					DefaultLogger.raiseLevel()
+				} else {
+					DefaultLogger.lowerLevel()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// reraise delivers the signal again once the logger stopped handling it,
+// terminating the process unless the program handles the signal itself
+func reraise(sig os.Signal) {
+	if s, ok := sig.(syscall.Signal); ok {
+		_ = syscall.Kill(os.Getpid(), s)
+	}
+}
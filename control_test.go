@@ -0,0 +1,60 @@
+package gologger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	l, w := newTestLogger()
+	handler := l.LevelHandler()
+	request := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, "/level", strings.NewReader(body)))
+		return recorder
+	}
+	level := func() string {
+		return request(http.MethodGet, "").Body.String()
+	}
+
+	if r := request(http.MethodGet, ""); r.Code != http.StatusOK || r.Body.String() != "{\"level\":\"debug\"}\n" {
+		t.Errorf("GET = %d %q", r.Code, r.Body)
+	}
+	if r := request(http.MethodPut, "error"); r.Code != http.StatusOK || r.Body.String() != "{\"level\":\"error\"}\n" {
+		t.Errorf("PUT error = %d %q", r.Code, r.Body)
+	}
+	l.Info().Msg("hidden")
+	l.Error().Msg("shown")
+	if got := w.String(); got != "[ERR] shown" {
+		t.Errorf("output = %q, want only the error", got)
+	}
+
+	if r := request(http.MethodPut, `{"level":"verbose"}`); r.Code != http.StatusOK || level() != "{\"level\":\"verbose\"}\n" {
+		t.Errorf("PUT json = %d %q, level %s", r.Code, r.Body, level())
+	}
+	if r := request(http.MethodPut, "loud"); r.Code != http.StatusBadRequest || level() != "{\"level\":\"verbose\"}\n" {
+		t.Errorf("PUT invalid = %d %q, level %s", r.Code, r.Body, level())
+	}
+	if r := request(http.MethodPost, "debug"); r.Code != http.StatusMethodNotAllowed || r.Header().Get("Allow") != "GET, PUT" {
+		t.Errorf("POST = %d, Allow %q", r.Code, r.Header().Get("Allow"))
+	}
+}
+
+func TestRaiseLowerLevel(t *testing.T) {
+	l, w := newTestLogger()
+	l.raiseLevel()
+	l.raiseLevel()
+	l.raiseLevel()
+	l.Verbose().Msg("verbose")
+	l.Trace().Msg("trace")
+	for i := 0; i < 10; i++ {
+		l.lowerLevel()
+	}
+	l.Error().Msg("error")
+	l.Print().Msg("result")
+	if got, want := w.String(), "[VER] verbose\n[TRC] trace\nresult"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
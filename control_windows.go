@@ -0,0 +1,15 @@
+//go:build windows
+
+package gologger
+
+import "os"
+
+// EnableLevelSignals is a no-op on Windows which has no SIGUSR1 and SIGUSR2
+func EnableLevelSignals() (stop func()) {
+	return func() {}
+}
+
+// reraise exits with status 1 as Windows signals can't be delivered again
+func reraise(sig os.Signal) {
+	os.Exit(1)
+}
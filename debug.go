@@ -0,0 +1,34 @@
+//go:build gologgerdebug
+
+package gologger
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// debugMode reports whether the package was built with the gologgerdebug tag
+const debugMode = true
+
+// trackEvent sets a finalizer reporting events garbage collected without
+// being emitted, usually a forgotten Msg call. The report panics when
+// GOLOGGER_DEBUG_PANIC is set.
+func trackEvent(e *Event) {
+	e.origin = captureStackTrace(4)
+	runtime.SetFinalizer(e, func(e *Event) {
+		if e.emitted {
+			return
+		}
+		reportDebug(fmt.Sprintf("gologger: event created but never emitted, missing Msg call?\n%s", e.origin))
+	})
+}
+
+// reportDebug prints the diagnostic on stderr, or panics with it when
+// GOLOGGER_DEBUG_PANIC is set
+func reportDebug(diagnostic string) {
+	if os.Getenv("GOLOGGER_DEBUG_PANIC") != "" {
+		panic(diagnostic)
+	}
+	fmt.Fprintln(os.Stderr, diagnostic)
+}
@@ -0,0 +1,12 @@
+//go:build !gologgerdebug
+
+package gologger
+
+// debugMode reports whether the package was built with the gologgerdebug tag
+const debugMode = false
+
+// trackEvent is a no-op without the gologgerdebug tag
+func trackEvent(e *Event) {}
+
+// reportDebug is a no-op without the gologgerdebug tag
+func reportDebug(diagnostic string) {}
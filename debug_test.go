@@ -0,0 +1,51 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestEventReuseGuard checks that reusing an event after Msg is reported in
+// debug builds, run with -tags gologgerdebug
+func TestEventReuseGuard(t *testing.T) {
+	if !debugMode {
+		// events are recycled once emitted, reusing them is undefined
+		t.Skip("requires the gologgerdebug build tag")
+	}
+	t.Setenv("GOLOGGER_DEBUG_PANIC", "1")
+
+	tests := []struct {
+		name       string
+		reuse      func(l *Logger, event *Event)
+		diagnostic string
+	}{
+		{"emitted twice", func(l *Logger, event *Event) { event.Msg("again") }, "event emitted twice"},
+		{"logged twice", func(l *Logger, event *Event) { l.Log(event) }, "event emitted twice"},
+		{"string field", func(l *Logger, event *Event) { event.Str("key", "value") }, "event mutated after being emitted"},
+		{"int field", func(l *Logger, event *Event) { event.Int("key", 1) }, "event mutated after being emitted"},
+		{"label", func(l *Logger, event *Event) { event.Label("RUN") }, "event mutated after being emitted"},
+		{"force", func(l *Logger, event *Event) { event.Force() }, "event mutated after being emitted"},
+		{"timestamp", func(l *Logger, event *Event) { event.TimeStamp() }, "event mutated after being emitted"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, _ := newTestLogger()
+			event := l.Info()
+			event.Msg("first")
+
+			var diagnostic string
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						diagnostic, _ = r.(string)
+					}
+				}()
+				test.reuse(l, event)
+			}()
+
+			if !strings.Contains(diagnostic, test.diagnostic) {
+				t.Errorf("diagnostic = %q, want %q", diagnostic, test.diagnostic)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package gologger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// deduplicator collapses identical consecutive events
+type deduplicator struct {
+	mutex       sync.Mutex
+	logger      *Logger
+	window      time.Duration
+	level       levels.Level
+	message     string
+	fingerprint uint64
+	since       time.Time
+	repeats     int
+	timer       *time.Timer
+}
+
+// SetDeduplication collapses identical consecutive events logged within
+// window, emitting a "last message repeated N times" event once the window
+// closes or a different event is logged. Events are identical when they have
+// the same level, message and fields, the timestamp aside. Fatal events are
+// never collapsed. A zero window disables it.
+func (l *Logger) SetDeduplication(window time.Duration) {
+	if window <= 0 {
+		l.deduplicator = nil
+		return
+	}
+	l.deduplicator = &deduplicator{logger: l, window: window}
+}
+
+// allow returns false if the event repeats the last one within the window
+func (d *deduplicator) allow(event *Event) bool {
+	if event.level == levels.LevelFatal {
+		// the fatal event must reach the exit, the pending summary goes first
+		d.flush()
+		return true
+	}
+	fingerprint := metadataFingerprint(event.metadata)
+	d.mutex.Lock()
+	now := time.Now()
+	if event.level == d.level && event.message == d.message && fingerprint == d.fingerprint && now.Sub(d.since) < d.window {
+		d.repeats++
+		if d.timer == nil {
+			d.timer = time.AfterFunc(d.since.Add(d.window).Sub(now), d.flush)
+		}
+		d.mutex.Unlock()
+		return false
+	}
+	summary := d.summarize()
+	d.level = event.level
+	d.message = event.message
+	d.fingerprint = fingerprint
+	d.since = now
+	d.mutex.Unlock()
+
+	// the summary is logged without holding the mutex so that the events
+	// being deduplicated don't wait for it to be written
+	if summary != nil {
+		d.logger.Log(summary)
+	}
+	return true
+}
+
+// flush emits the pending summary once the window is closed
+func (d *deduplicator) flush() {
+	d.mutex.Lock()
+	summary := d.summarize()
+	d.message = ""
+	d.mutex.Unlock()
+
+	if summary != nil {
+		d.logger.Log(summary)
+	}
+}
+
+// summarize returns the repeated summary event to log, or nil if the last
+// message was not repeated. It must be called with the mutex held.
+func (d *deduplicator) summarize() *Event {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if d.repeats == 0 {
+		return nil
+	}
+	// the summary must not trigger the fatal exit on its own
+	level := d.level
+	if level == levels.LevelFatal {
+		level = levels.LevelError
+	}
+	event := newEventWithLevelAndLogger(level, d.logger)
+	if _, ok := labels[level]; ok {
+		event.setLevelMetadata(level)
+	}
+	event.Int("repeated", d.repeats)
+	event.message = "last message repeated " + strconv.Itoa(d.repeats) + " times"
+	event.forced = true
+	d.repeats = 0
+	return event
+}
+
+// metadataFingerprint returns a hash of the event fields, the timestamp
+// being left out as it changes between identical events
+func metadataFingerprint(metadata map[string]interface{}) uint64 {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		if k != "timestamp" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	h := fnv.New64a()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%v\x00", k, metadata[k])
+	}
+	return h.Sum64()
+}
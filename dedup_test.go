@@ -0,0 +1,114 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestDeduplication(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetDeduplication(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		l.Info().Msg("connection refused")
+	}
+	l.Info().Msg("connected")
+
+	expected := []string{
+		"[INF] connection refused",
+		"[INF] last message repeated 2 times repeated=2",
+		"[INF] connected",
+	}
+	if lines := w.Lines(); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got %q, want %q", lines, expected)
+	}
+}
+
+// slowWriter blocks the writes of the summaries until released
+type slowWriter struct {
+	bufferWriter
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (w *slowWriter) Write(data []byte, level levels.Level) {
+	if strings.Contains(string(data), "repeated") {
+		close(w.blocked)
+		<-w.release
+	}
+	w.bufferWriter.Write(data, level)
+}
+
+func TestDeduplicationSummaryDoesNotBlock(t *testing.T) {
+	l, _ := newTestLogger()
+	w := &slowWriter{blocked: make(chan struct{}), release: make(chan struct{})}
+	l.SetWriter(w)
+	l.SetDeduplication(time.Hour)
+
+	l.Info().Msg("a")
+	l.Info().Msg("a")
+	go l.Info().Msg("b")
+	<-w.blocked
+
+	done := make(chan struct{})
+	go func() {
+		l.Info().Msg("c")
+		l.Info().Msg("c")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("deduplicated events waited for the summary to be written")
+	}
+	close(w.release)
+}
+
+func TestDeduplicationFields(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetDeduplication(time.Hour)
+
+	l.Info().Str("host", "a.example.com").Msg("connection refused")
+	l.Info().Str("host", "b.example.com").Msg("connection refused")
+	l.Info().Str("host", "b.example.com").Msg("connection refused")
+	l.Info().Msg("connected")
+
+	expected := []string{
+		"[INF] connection refused host=a.example.com",
+		"[INF] connection refused host=b.example.com",
+		"[INF] last message repeated 1 times repeated=1",
+		"[INF] connected",
+	}
+	if lines := w.Lines(); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got %q, want %q", lines, expected)
+	}
+}
+
+func TestDeduplicationFatal(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetFatalPolicy(FatalReturn)
+	l.SetDeduplication(time.Hour)
+	exits := 0
+	l.RegisterExitHook(func() { exits++ })
+
+	l.Info().Msg("retrying")
+	l.Info().Msg("retrying")
+	l.Fatal().Msg("giving up")
+	l.Fatal().Msg("giving up")
+
+	expected := []string{
+		"[INF] retrying",
+		"[INF] last message repeated 1 times repeated=1",
+		"[FTL] giving up exit_code=1",
+		"[FTL] giving up exit_code=1",
+	}
+	if lines := w.Lines(); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got %q, want %q", lines, expected)
+	}
+	if exits != 2 {
+		t.Errorf("exited %d times, want 2", exits)
+	}
+}
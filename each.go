@@ -0,0 +1,71 @@
+package gologger
+
+import (
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Each emits one event per element of items at the given level. The
+// callback receives a reused event and the item, and is expected to add
+// fields and terminate the event with Msg, Msgf or MsgFunc.
+//
+// The event passed to fn is recycled for the next item so it must not
+// be retained after fn returns.
+func Each[T any](l *Logger, level levels.Level, items []T, fn func(*Event, T)) {
+	if !l.isLevelEnabled(level) {
+		return
+	}
+	event := eventPool.Get().(*Event)
+	defer recycleEvent(event)
+
+	for _, item := range items {
+		event.reset(level, l)
+		fn(event, item)
+	}
+}
+
+// EachSync is like Each but serializes concurrent batches on the same
+// logger so that the events of a batch are written contiguously.
+func EachSync[T any](l *Logger, level levels.Level, items []T, fn func(*Event, T)) {
+	l.batchMutex.Lock()
+	defer l.batchMutex.Unlock()
+
+	Each(l, level, items, fn)
+}
+
+// reset prepares a pooled event for reuse with the given level and logger
+func (e *Event) reset(level levels.Level, l *Logger) {
+	for k := range e.metadata {
+		delete(e.metadata, k)
+	}
+	e.named = nil
+	e.emitted = false
+	e.pooled = false
+	if l.parent != nil {
+		e.named = l
+		for l.parent != nil {
+			l = l.parent
+		}
+	}
+	e.logger = l
+	e.level = level
+	e.message = ""
+	e.forced = false
+	e.transient = false
+	e.lazy = false
+	e.verbosity = 0
+	e.time = time.Now()
+	if _, ok := labels[level]; ok {
+		e.setLevelMetadata(level)
+	}
+	if l.timestamp && level.Rank() >= l.timestampMinLevel.Rank() {
+		e.TimeStamp()
+	}
+	for k, v := range l.fields {
+		e.metadata[k] = v
+	}
+	if e.named != nil && e.named.component != "" {
+		e.metadata["component"] = e.named.component
+	}
+}
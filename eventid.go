@@ -0,0 +1,92 @@
+package gologger
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// IDGenerator returns the id of an event, see SetIDGenerator
+type IDGenerator func(event *Event) string
+
+// SetEventIDs enables/disables deterministic event ids. When enabled each
+// event gets an "event_id" computed from its level, message, metadata and
+// sequence number so that downstream shippers can deduplicate retries.
+func (l *Logger) SetEventIDs(enabled bool) {
+	l.eventIDs = enabled
+}
+
+// SetIDGenerator stamps every event with an "event_id" returned by the
+// generator, e.g. UUID or ULID, so that individual events can be referenced
+// from tickets and correlation tools. A nil generator restores the
+// deterministic ids of SetEventIDs, which stays enabled.
+func (l *Logger) SetIDGenerator(generator IDGenerator) {
+	l.idGenerator = generator
+	l.eventIDs = true
+}
+
+// UUID returns a random (version 4) UUID, for SetIDGenerator
+func UUID(event *Event) string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	var buffer [36]byte
+	hex.Encode(buffer[0:8], id[0:4])
+	buffer[8] = '-'
+	hex.Encode(buffer[9:13], id[4:6])
+	buffer[13] = '-'
+	hex.Encode(buffer[14:18], id[6:8])
+	buffer[18] = '-'
+	hex.Encode(buffer[19:23], id[8:10])
+	buffer[23] = '-'
+	hex.Encode(buffer[24:], id[10:])
+	return string(buffer[:])
+}
+
+// crockford is the base32 alphabet of ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID returns a ULID made of the event time and random bits, which sorts
+// lexicographically by time, for SetIDGenerator
+func ULID(event *Event) string {
+	var id [16]byte
+	t := event.time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	binary.BigEndian.PutUint64(id[:8], uint64(t.UnixMilli())<<16)
+	_, _ = rand.Read(id[6:])
+
+	// 128 bits are encoded as 26 characters of 5 bits, the first one holding 3
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	var buffer [26]byte
+	for i := 25; i >= 0; i-- {
+		buffer[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buffer[:])
+}
+
+// eventID returns the deterministic id for the event
+func (l *Logger) eventID(event *Event) string {
+	hasher := fnv.New64a()
+	fmt.Fprintf(hasher, "%d\x00%s\x00%d", event.level, event.message, l.sequence.Add(1))
+
+	keys := make([]string, 0, len(event.metadata))
+	for k := range event.metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(hasher, "\x00%s=%v", k, event.metadata[k])
+	}
+	return strconv.FormatUint(hasher.Sum64(), 16)
+}
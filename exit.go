@@ -0,0 +1,215 @@
+package gologger
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// FatalPolicy defines what happens once a Fatal event has been logged
+type FatalPolicy int
+
+// Available fatal policies
+const (
+	// FatalExit terminates the process with the exit function (os.Exit by default)
+	FatalExit FatalPolicy = iota
+	// FatalPanic panics with a *FatalError
+	FatalPanic
+	// FatalReturn returns to the caller
+	FatalReturn
+)
+
+// FatalError is the panic value used by the FatalPanic policy
+type FatalError struct {
+	Code    int
+	Message string
+}
+
+// Error returns the error message
+func (e *FatalError) Error() string {
+	return fmt.Sprintf("fatal: %s (exit code %d)", e.Message, e.Code)
+}
+
+// SetExitFunc sets the function called to terminate the process on Fatal
+// events, os.Exit by default
+func (l *Logger) SetExitFunc(exitFunc func(code int)) {
+	l.exitFunc = exitFunc
+}
+
+// SetFatalPolicy sets the behavior once a Fatal event has been logged
+func (l *Logger) SetFatalPolicy(policy FatalPolicy) {
+	l.fatalPolicy = policy
+}
+
+// DefaultExitHookTimeout is the default maximum duration of the exit hooks
+const DefaultExitHookTimeout = 5 * time.Second
+
+// RegisterExitHook registers a function run before terminating on Fatal
+// events, e.g. to close result files, release locks or notify an
+// orchestrator. Hooks run in registration order, a panicking hook doesn't
+// prevent the next ones from running.
+func (l *Logger) RegisterExitHook(hook func()) {
+	l.exitHooks = append(l.exitHooks, hook)
+}
+
+// RegisterExitHook registers a function run before the default logger
+// terminates on Fatal events
+func RegisterExitHook(hook func()) {
+	DefaultLogger.RegisterExitHook(hook)
+}
+
+// SetExitHookTimeout sets the maximum duration of the exit hooks, after
+// which the process terminates without waiting for the remaining ones.
+// DefaultExitHookTimeout is used when 0, a negative timeout waits for the
+// hooks indefinitely.
+func (l *Logger) SetExitHookTimeout(timeout time.Duration) {
+	l.exitHookTimeout = timeout
+}
+
+// SetExitHookErrorHandler sets the function called when an exit hook panics
+// or the exit hooks exceed the exit hook timeout. The errors are logged at
+// the error level by the logger when nil.
+func (l *Logger) SetExitHookErrorHandler(handler func(err error)) {
+	l.exitHookErrors = handler
+}
+
+// exitHookError reports the exit hook error to the handler or the logger
+func (l *Logger) exitHookError(err error) {
+	if l.exitHookErrors != nil {
+		l.exitHookErrors(err)
+		return
+	}
+	l.Error().Force().Msg(err.Error())
+}
+
+// runExitHooks runs the exit hooks within the exit hook timeout
+func (l *Logger) runExitHooks() {
+	if len(l.exitHooks) == 0 {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, hook := range l.exitHooks {
+			l.runExitHook(hook)
+		}
+	}()
+
+	timeout := l.exitHookTimeout
+	if timeout == 0 {
+		timeout = DefaultExitHookTimeout
+	}
+	if timeout < 0 {
+		<-done
+		return
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		l.exitHookError(fmt.Errorf("exit hooks did not complete within %s", timeout))
+	}
+}
+
+func (l *Logger) runExitHook(hook func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.exitHookError(fmt.Errorf("exit hook panicked: %v", r))
+		}
+	}()
+	hook()
+}
+
+// exit runs the exit hooks, flushes the writers and applies the fatal policy.
+// The writers are closed when the process terminates.
+func (l *Logger) exit(code int, message string) {
+	l.runExitHooks()
+
+	switch l.fatalPolicy {
+	case FatalPanic:
+		l.flush()
+		panic(&FatalError{Code: code, Message: message})
+	case FatalReturn:
+		l.flush()
+		return
+	default:
+		_ = l.Close()
+		exitFunc := l.exitFunc
+		if exitFunc == nil {
+			exitFunc = os.Exit
+		}
+		exitFunc(code)
+	}
+}
+
+// SetExitCode maps a fatal category to the exit code used when a Fatal
+// event tagged with that category is logged
+func (l *Logger) SetExitCode(category string, code int) {
+	if l.exitCodes == nil {
+		l.exitCodes = make(map[string]int)
+	}
+	l.exitCodes[category] = code
+}
+
+// Category tags the event with a category, used to pick the exit code of Fatal events
+func (e *Event) Category(category string) *Event {
+	e.checkNotEmitted()
+	e.metadata["category"] = category
+	return e
+}
+
+// Die logs a fatal event with the alternating key/value fields and exits
+// with the given code
+func (l *Logger) Die(code int, msg string, fields ...interface{}) {
+	event := newDieEvent(l, code, msg, fields)
+	event.setCaller()
+	l.Log(event)
+}
+
+// Die logs a fatal event on the default logger and exits with the given code
+func Die(code int, msg string, fields ...interface{}) {
+	event := newDieEvent(DefaultLogger, code, msg, fields)
+	event.setCaller()
+	DefaultLogger.Log(event)
+}
+
+func newDieEvent(l *Logger, code int, msg string, fields []interface{}) *Event {
+	event := newEventWithLevelAndLogger(levels.LevelFatal, l)
+	event.setLevelMetadata(levels.LevelFatal)
+	event.addPairs(fields)
+	event.metadata["exit_code"] = code
+	event.message = msg
+	return event
+}
+
+// exitCode returns the exit code for a fatal event: an explicit exit_code,
+// the code mapped to its category or 1. The resolved code is recorded in
+// the event metadata.
+func (l *Logger) exitCode(event *Event) int {
+	if code, ok := event.metadata["exit_code"].(int); ok {
+		return code
+	}
+	code := 1
+	if category, ok := event.metadata["category"].(string); ok {
+		if mapped, ok := l.exitCodes[category]; ok {
+			code = mapped
+		}
+	}
+	event.metadata["exit_code"] = code
+	return code
+}
+
+// addPairs adds alternating key/value pairs to the event metadata.
+// A trailing key without value is ignored.
+func (e *Event) addPairs(pairs []interface{}) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			key = fmt.Sprint(pairs[i])
+		}
+		e.metadata[key] = pairs[i+1]
+	}
+}
@@ -0,0 +1,58 @@
+package gologger
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExitHookErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		hook    func()
+		timeout time.Duration
+		err     string
+	}{
+		{"panic", func() { panic("boom") }, 0, "exit hook panicked: boom"},
+		{"timeout", func() { time.Sleep(time.Second) }, 10 * time.Millisecond, "exit hooks did not complete within 10ms"},
+		{"success", func() {}, 0, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, _ := newTestLogger()
+			l.SetFatalPolicy(FatalReturn)
+			l.SetExitHookTimeout(test.timeout)
+			l.RegisterExitHook(test.hook)
+
+			var mutex sync.Mutex
+			var errs []string
+			l.SetExitHookErrorHandler(func(err error) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				errs = append(errs, err.Error())
+			})
+			l.Fatal().Msg("fatal")
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if test.err == "" && len(errs) > 0 {
+				t.Errorf("unexpected errors %q", errs)
+			}
+			if test.err != "" && (len(errs) != 1 || errs[0] != test.err) {
+				t.Errorf("errors = %q, want %q", errs, test.err)
+			}
+		})
+	}
+}
+
+func TestExitHookErrorsLogged(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetFatalPolicy(FatalReturn)
+	l.RegisterExitHook(func() { panic("boom") })
+	l.Fatal().Msg("fatal")
+
+	if output := w.String(); !strings.Contains(output, "[ERR] exit hook panicked: boom") {
+		t.Errorf("exit hook error not logged, got %q", output)
+	}
+}
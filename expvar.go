@@ -0,0 +1,17 @@
+package gologger
+
+import "expvar"
+
+// PublishExpvar publishes the logger stats as an expvar variable with the
+// given name, making them available on /debug/vars. Like expvar.Publish it
+// panics if the name is already registered.
+func (l *Logger) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return l.Stats()
+	}))
+}
+
+// PublishExpvar publishes the default logger stats as an expvar variable
+func PublishExpvar(name string) {
+	DefaultLogger.PublishExpvar(name)
+}
@@ -0,0 +1,165 @@
+package gologger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Int adds an int metadata item to the log
+func (e *Event) Int(key string, value int) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Int64 adds an int64 metadata item to the log
+func (e *Event) Int64(key string, value int64) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Uint64 adds an uint64 metadata item to the log
+func (e *Event) Uint64(key string, value uint64) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Bool adds a boolean metadata item to the log
+func (e *Event) Bool(key string, value bool) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Float64 adds a float64 metadata item to the log
+func (e *Event) Float64(key string, value float64) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Dur adds a duration metadata item to the log
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Time adds a time metadata item to the log
+func (e *Event) Time(key string, value time.Time) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
+
+// Fields adds all the items of the map to the event metadata
+func (e *Event) Fields(fields map[string]interface{}) *Event {
+	e.checkNotEmitted()
+	for k, v := range fields {
+		e.metadata[k] = v
+	}
+	return e
+}
+
+// Dict adds a nested object built from alternating key/value pairs, which
+// is rendered as a nested JSON object and as key.sub items by the text
+// formatters. Values can be nested maps built by other Dict calls.
+func (e *Event) Dict(key string, pairs ...interface{}) *Event {
+	e.checkNotEmitted()
+	dict := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		k, ok := pairs[i].(string)
+		if !ok {
+			k = fmt.Sprint(pairs[i])
+		}
+		dict[k] = pairs[i+1]
+	}
+	e.metadata[key] = dict
+	return e
+}
+
+// Err adds the error message under the "error" key. A nil error is ignored.
+// If stack traces are enabled on the logger, Error and Fatal events also
+// get the stack trace of the caller under the "stacktrace" key.
+func (e *Event) Err(err error) *Event {
+	e.checkNotEmitted()
+	if err == nil {
+		return e
+	}
+	e.metadata["error"] = err
+	if e.logger.stackTraces && (e.level == levels.LevelError || e.level == levels.LevelFatal) {
+		e.metadata["stacktrace"] = captureStackTrace(3)
+	}
+	return e
+}
+
+// captureStackTrace returns the formatted stack trace skipping the given frames
+func captureStackTrace(skip int) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var builder strings.Builder
+	for {
+		frame, more := frames.Next()
+		if builder.Len() > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return builder.String()
+}
+
+// Bytes adds a byte slice metadata item to the log as a string
+func (e *Event) Bytes(key string, value []byte) *Event {
+	e.checkNotEmitted()
+	if e.logger.interner != nil {
+		e.metadata[key] = e.logger.interner.internBytes(value)
+		return e
+	}
+	e.metadata[key] = string(value)
+	return e
+}
+
+// Enum adds a categorical metadata item normalized to lower case, keeping
+// values like protocol=tcp consistent. In gologgerdebug builds values not
+// in the allowed set are reported.
+func (e *Event) Enum(key, value string, allowed ...string) *Event {
+	e.checkNotEmitted()
+	value = strings.ToLower(value)
+	if debugMode && len(allowed) > 0 && !isAllowedEnum(value, allowed) {
+		reportDebug(fmt.Sprintf("gologger: invalid value %q for enum field %q, allowed values: %s\n%s",
+			value, key, strings.Join(allowed, ", "), captureStackTrace(3)))
+	}
+	e.metadata[key] = value
+	return e
+}
+
+func isAllowedEnum(value string, allowed []string) bool {
+	for _, v := range allowed {
+		if strings.EqualFold(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Any adds an arbitrary metadata item to the log. The value is
+// marshaled as-is by structured formatters.
+func (e *Event) Any(key string, value interface{}) *Event {
+	e.checkNotEmitted()
+	e.metadata[key] = value
+	return e
+}
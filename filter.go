@@ -0,0 +1,313 @@
+package gologger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Filter decides whether an event is kept
+type Filter interface {
+	// Match returns true if the event should be logged
+	Match(event *Event) bool
+}
+
+// FilterFunc is an adapter to use functions as filters
+type FilterFunc func(event *Event) bool
+
+// Match returns f(event)
+func (f FilterFunc) Match(event *Event) bool {
+	return f(event)
+}
+
+// SetFilter sets the filter of the logger, the events not matching it are
+// dropped. Fatal, audit and forced events are always logged. A nil filter
+// keeps all events.
+func (l *Logger) SetFilter(filter Filter) {
+	l.filter = filter
+}
+
+// ParseFilter parses a filter expression such as
+//
+//	level>=warn AND host!=localhost OR label=RESULT
+//
+// so that a --log-filter flag can be passed straight to the logger.
+// Comparisons are made of a field, an operator (=, !=, <, <=, > or >=) and a
+// value, optionally double quoted. They can be combined with AND, OR, NOT
+// (or &&, || and !) and parentheses, AND binding tighter than OR.
+//
+// The level field is compared by severity (trace < verbose < debug < info
+// < audit < warning < error < fatal), msg is the message, label the event label and
+// other fields are metadata items, compared as numbers when both sides are
+// numeric. Missing metadata items are empty.
+func ParseFilter(expr string) (Filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in filter", p.tokens[p.pos].text)
+	}
+	return node, nil
+}
+
+// filtered reports whether the event is dropped by the logger filter
+func (l *Logger) filtered(event *Event) bool {
+	return l.filter != nil && event.level != levels.LevelFatal && !event.forced && !l.filter.Match(event)
+}
+
+type filterTokenKind int
+
+const (
+	filterWord filterTokenKind = iota
+	filterString
+	filterOperator
+	filterOpen
+	filterClose
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{kind: filterOpen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{kind: filterClose, text: ")"})
+			i++
+		case c == '"':
+			value, err := strconv.QuotedPrefix(expr[i:])
+			if err != nil {
+				return nil, fmt.Errorf("unterminated string in filter at offset %d", i)
+			}
+			unquoted, _ := strconv.Unquote(value)
+			tokens = append(tokens, filterToken{kind: filterString, text: unquoted})
+			i += len(value)
+		case strings.ContainsRune("=!<>&|", rune(c)):
+			j := i + 1
+			for j < len(expr) && strings.ContainsRune("=&|", rune(expr[j])) && j-i < 2 {
+				j++
+			}
+			op := expr[i:j]
+			switch op {
+			case "=", "==", "!=", "<", "<=", ">", ">=", "&&", "||", "!":
+			default:
+				return nil, fmt.Errorf("invalid operator %q in filter", op)
+			}
+			tokens = append(tokens, filterToken{kind: filterOperator, text: op})
+			i = j
+		default:
+			j := i
+			for j < len(expr) && !unicode.IsSpace(rune(expr[j])) && !strings.ContainsRune("()=!<>&|\"", rune(expr[j])) {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: filterWord, text: expr[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// accept consumes the next token if it is one of the keywords or operators
+func (p *filterParser) accept(keyword, operator string) bool {
+	token, ok := p.peek()
+	if !ok {
+		return false
+	}
+	if (token.kind == filterWord && strings.EqualFold(token.text, keyword)) || (token.kind == filterOperator && token.text == operator) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("OR", "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		left = FilterFunc(func(event *Event) bool { return a.Match(event) || b.Match(event) })
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("AND", "&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		left = FilterFunc(func(event *Event) bool { return a.Match(event) && b.Match(event) })
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.accept("NOT", "!") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return FilterFunc(func(event *Event) bool { return !inner.Match(event) }), nil
+	}
+	token, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	if token.kind == filterOpen {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.kind != filterClose {
+			return nil, fmt.Errorf("missing closing parenthesis in filter")
+		}
+		p.pos++
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (Filter, error) {
+	if len(p.tokens)-p.pos < 3 {
+		return nil, fmt.Errorf("incomplete comparison in filter")
+	}
+	field, op, value := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if field.kind != filterWord {
+		return nil, fmt.Errorf("expected field name in filter, got %q", field.text)
+	}
+	if op.kind != filterOperator || op.text == "&&" || op.text == "||" || op.text == "!" {
+		return nil, fmt.Errorf("expected comparison operator after %q in filter", field.text)
+	}
+	if value.kind != filterWord && value.kind != filterString {
+		return nil, fmt.Errorf("expected value after %q in filter", field.text+op.text)
+	}
+	p.pos += 3
+
+	if strings.EqualFold(field.text, "level") {
+		level, err := levels.Parse(value.text)
+		if err != nil {
+			return nil, err
+		}
+		want := severity(level)
+		return FilterFunc(func(event *Event) bool {
+			return compareResult(severity(event.level)-want, op.text)
+		}), nil
+	}
+	return FilterFunc(func(event *Event) bool {
+		return compareValues(filterField(event, field.text), value.text, op.text)
+	}), nil
+}
+
+// severity orders the levels from the least to the most severe
+func severity(level levels.Level) int {
+	switch level {
+	case levels.LevelFatal:
+		return 7
+	case levels.LevelError:
+		return 6
+	case levels.LevelWarning:
+		return 5
+	case levels.LevelAudit:
+		return 4
+	case levels.LevelInfo, levels.LevelSilent:
+		return 3
+	case levels.LevelDebug:
+		return 2
+	case levels.LevelVerbose:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// filterField returns the textual value of the event field
+func filterField(event *Event, name string) string {
+	switch name {
+	case "msg", "message":
+		return event.message
+	}
+	value, ok := event.metadata[name]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+func compareValues(actual, expected, op string) bool {
+	a, errA := strconv.ParseFloat(actual, 64)
+	b, errB := strconv.ParseFloat(expected, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case a < b:
+			return compareResult(-1, op)
+		case a > b:
+			return compareResult(1, op)
+		default:
+			return compareResult(0, op)
+		}
+	}
+	return compareResult(strings.Compare(actual, expected), op)
+}
+
+// compareResult applies the operator to the result of a comparison
+func compareResult(cmp int, op string) bool {
+	switch op {
+	case "=", "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
@@ -0,0 +1,107 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestFilterForcedEvents(t *testing.T) {
+	l, w := newTestLogger()
+	filter, err := ParseFilter("level>=warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetFilter(filter)
+
+	l.Info().Msg("dropped")
+	l.Info().Force().Msg("forced")
+	l.Audit().Msg("audited")
+	l.Error().Msg("kept")
+
+	output := w.String()
+	if strings.Contains(output, "dropped") {
+		t.Errorf("unforced info event was not filtered:\n%s", output)
+	}
+	for _, message := range []string{"forced", "audited", "kept"} {
+		if !strings.Contains(output, message) {
+			t.Errorf("%q event was filtered:\n%s", message, output)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr     string
+		level    levels.Level
+		message  string
+		metadata map[string]interface{}
+		match    bool
+	}{
+		{"level>=warn", levels.LevelError, "", nil, true},
+		{"level>=warn", levels.LevelWarning, "", nil, true},
+		{"level>=warn", levels.LevelInfo, "", nil, false},
+		{"level<info", levels.LevelDebug, "", nil, true},
+		{"level=info", levels.LevelSilent, "", nil, true},
+		{"level>info", levels.LevelAudit, "", nil, true},
+		{"host!=localhost", levels.LevelInfo, "", map[string]interface{}{"host": "example.com"}, true},
+		{"host!=localhost", levels.LevelInfo, "", map[string]interface{}{"host": "localhost"}, false},
+		{"host=localhost", levels.LevelInfo, "", nil, false},
+		{"host=\"\"", levels.LevelInfo, "", nil, true},
+		{"label=RESULT", levels.LevelInfo, "", map[string]interface{}{"label": "RESULT"}, true},
+		{"msg=\"scan done\"", levels.LevelInfo, "scan done", nil, true},
+		{"status>=400", levels.LevelInfo, "", map[string]interface{}{"status": 404}, true},
+		{"status>=400", levels.LevelInfo, "", map[string]interface{}{"status": 200}, false},
+		{"status>=400", levels.LevelInfo, "", map[string]interface{}{"status": 99}, false},
+		{"name<b", levels.LevelInfo, "", map[string]interface{}{"name": "abc"}, true},
+		{"level>=warn AND host!=localhost OR label=RESULT", levels.LevelInfo, "", map[string]interface{}{"label": "RESULT"}, true},
+		{"level>=warn AND host!=localhost OR label=RESULT", levels.LevelError, "", map[string]interface{}{"host": "localhost"}, false},
+		{"level>=warn and (host!=localhost or label=RESULT)", levels.LevelError, "", map[string]interface{}{"host": "localhost", "label": "RESULT"}, true},
+		{"NOT level=debug", levels.LevelDebug, "", nil, false},
+		{"!(level=debug) && msg!=x", levels.LevelInfo, "y", nil, true},
+		{"level<=info || status==1", levels.LevelError, "", map[string]interface{}{"status": 1.0}, true},
+	}
+	for _, test := range tests {
+		filter, err := ParseFilter(test.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) failed: %v", test.expr, err)
+			continue
+		}
+		metadata := test.metadata
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		event := &Event{level: test.level, message: test.message, metadata: metadata}
+		if got := filter.Match(event); got != test.match {
+			t.Errorf("%q on %s %q %v = %v, want %v", test.expr, test.level, test.message, test.metadata, got, test.match)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"", "unexpected end of filter"},
+		{"level", "incomplete comparison in filter"},
+		{"level>=", "incomplete comparison in filter"},
+		{"level>=warn AND", "unexpected end of filter"},
+		{"(level>=warn", "missing closing parenthesis in filter"},
+		{"level>=warn)", `unexpected ")" in filter`},
+		{"level&=warn", `invalid operator "&=" in filter`},
+		{"level=>warn", `expected value after "level=" in filter`},
+		{"host=\"localhost", "unterminated string in filter at offset 5"},
+		{"\"host\"=localhost", `expected field name in filter, got "host"`},
+		{"host localhost x", `expected comparison operator after "host" in filter`},
+		{"host=(", `expected value after "host=" in filter`},
+		{"level=loud", "invalid level"},
+	}
+	for _, test := range tests {
+		_, err := ParseFilter(test.expr)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("ParseFilter(%q) error = %v, want %q", test.expr, err, test.err)
+		}
+	}
+}
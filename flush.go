@@ -0,0 +1,61 @@
+package gologger
+
+import (
+	"errors"
+
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// flusher is implemented by writers buffering or caching data
+type flusher interface {
+	Flush() error
+}
+
+// Barrier blocks until the events logged before the call have been written
+// by the logger writers, including buffered and asynchronous ones, so that
+// they are ordered before anything logged or printed afterwards.
+func (l *Logger) Barrier() {
+	l.flush()
+}
+
+// Barrier blocks until the events logged on the default logger before the
+// call have been written
+func Barrier() {
+	DefaultLogger.Barrier()
+}
+
+// flush flushes the logger writers supporting it
+func (l *Logger) flush() {
+	_, w := l.output()
+	if f, ok := w.(flusher); ok {
+		_ = f.Flush()
+	}
+	for _, sink := range l.loadSinks() {
+		if f, ok := sink.Writer.(flusher); ok {
+			_ = f.Flush()
+		}
+	}
+}
+
+// Close flushes and closes the logger writers, including the sinks, so that
+// buffered output is not lost at program end. The logger must not be used
+// afterwards. It is called automatically before Fatal events exit.
+func (l *Logger) Close() error {
+	var errs []error
+	if _, w := l.output(); w != nil {
+		if err := writer.Close(w); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, sink := range l.loadSinks() {
+		if err := writer.Close(sink.Writer); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close flushes and closes the writers of the default logger
+func Close() error {
+	return DefaultLogger.Close()
+}
@@ -0,0 +1,111 @@
+package formatter
+
+import (
+	"os"
+	"os/user"
+	"sort"
+	"time"
+)
+
+// Audit is a formatter for outputting audit trail json events with the
+// fields commonly required by compliance frameworks, e.g. for the audit
+// trails of pentest engagements:
+//
+//   - when: the event time in UTC
+//   - who: the "who" or "user" metadata item, the process user by default
+//   - what: the "what" metadata item, the message by default
+//   - where: the "where" or "target" metadata item, the host by default
+//   - outcome: the "outcome" metadata item, "unknown" by default
+//
+// They are followed by the host, the level, the message and the other
+// metadata items in sorted order, the items named like one of the standard
+// fields being prefixed with an underscore.
+type Audit struct {
+	// Host is the name of the host writing the events
+	Host string
+	// User is the default actor of the events
+	User string
+}
+
+var _ Formatter = &Audit{}
+
+// NewAudit returns a new audit formatter using the machine hostname and the
+// process user
+func NewAudit() *Audit {
+	audit := &Audit{Host: "localhost"}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		audit.Host = host
+	}
+	if current, err := user.Current(); err == nil {
+		audit.User = current.Username
+	}
+	return audit
+}
+
+// Format formats the log event data into bytes
+func (a *Audit) Format(event *LogEvent) ([]byte, error) {
+	metadata := event.Metadata
+	delete(metadata, "timestamp")
+	delete(metadata, "label")
+
+	fields := [...]struct{ key, value string }{
+		{"when", eventTime(event).UTC().Format(time.RFC3339Nano)},
+		{"who", auditField(metadata, a.User, "who", "user")},
+		{"what", auditField(metadata, event.Message, "what")},
+		{"where", auditField(metadata, a.Host, "where", "target")},
+		{"outcome", auditField(metadata, "unknown", "outcome")},
+		{"host", a.Host},
+		{"level", event.Level.String()},
+		{"message", event.Message},
+	}
+	buffer := make([]byte, 0, 256+len(event.Message))
+	buffer = append(buffer, '{')
+	for i, field := range fields {
+		if i > 0 {
+			buffer = append(buffer, ',')
+		}
+		buffer = appendJSONString(buffer, field.key)
+		buffer = append(buffer, ':')
+		buffer = appendJSONString(buffer, field.value)
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var err error
+	for _, k := range keys {
+		buffer = append(buffer, ',')
+		name := k
+		for _, field := range fields {
+			if field.key == k {
+				name = "_" + k
+				break
+			}
+		}
+		buffer = appendJSONString(buffer, name)
+		buffer = append(buffer, ':')
+		if buffer, err = appendJSONValue(buffer, metadata[k]); err != nil {
+			return nil, err
+		}
+	}
+	buffer = append(buffer, '}')
+	return buffer, nil
+}
+
+// auditField returns and removes the first metadata item found among the
+// keys, or the fallback value
+func auditField(metadata map[string]interface{}, fallback string, keys ...string) string {
+	for _, key := range keys {
+		value, ok := metadata[key]
+		if !ok {
+			continue
+		}
+		delete(metadata, key)
+		if s := stringify(value); s != "" {
+			return s
+		}
+	}
+	return fallback
+}
@@ -2,32 +2,139 @@ package formatter
 
 import (
 	"bytes"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
 
-	"github.com/logrusorgru/aurora"
 	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
 )
 
 // CLI is a formatter for outputting CLI logs
 type CLI struct {
 	NoUseColors bool
-	aurora      aurora.Aurora
+	Theme       ColorTheme
+	// MultiLine writes each metadata item on its own indented line with
+	// aligned keys instead of key=value pairs after the message
+	MultiLine bool
+	// AlignColumns pads the label and timestamp into fixed width columns
+	// so that the messages line up vertically
+	AlignColumns bool
+	// LabelWidth is the width of the label column when aligning columns,
+	// 3 by default. Longer labels are not truncated.
+	LabelWidth int
+
+	// timestampWidth is the width of the widest timestamp seen, used to
+	// pad the events without timestamp
+	timestampWidth atomic.Int64
+	// colorMode is the mode set with SetColorMode, see ColorMode
+	colorMode    ColorMode
+	colorModeSet bool
 }
 
-var _ Formatter = &CLI{}
+var (
+	_ Formatter = &CLI{}
+	_ Appender  = &CLI{}
+)
+
+// ColorMode controls when the CLI formatter uses colors
+type ColorMode int
 
-// NewCLI returns a new CLI based formatter
+// Available color modes
+const (
+	// ColorAuto uses colors when the writer of the logger supports them,
+	// or when stderr is a terminal supporting them for formatters used on
+	// their own
+	ColorAuto ColorMode = iota
+	// ColorAlways always uses colors
+	ColorAlways
+	// ColorNever never uses colors
+	ColorNever
+)
+
+// NewCLI returns a new CLI based formatter. Unless disabled, colors are
+// used only when the terminal supports them.
 func NewCLI(noUseColors bool) *CLI {
-	return &CLI{NoUseColors: noUseColors, aurora: aurora.NewAurora(!noUseColors)}
+	if noUseColors {
+		return NewCLIWithColorMode(ColorNever)
+	}
+	return NewCLIWithColorMode(ColorAuto)
+}
+
+// NewCLIWithColorMode returns a new CLI based formatter with the color mode
+func NewCLIWithColorMode(mode ColorMode) *CLI {
+	cli := &CLI{Theme: DefaultColorTheme}
+	cli.SetColorMode(mode)
+	return cli
+}
+
+// NewCLIWithTheme returns a new CLI based formatter using the color theme
+// when the terminal supports colors
+func NewCLIWithTheme(theme ColorTheme) *CLI {
+	cli := &CLI{Theme: theme}
+	cli.SetColorMode(ColorAuto)
+	return cli
+}
+
+// NewCLIDev returns a new CLI based formatter for local development,
+// writing the message on one line followed by the metadata items sorted by
+// key on indented lines with aligned keys and colorized values
+func NewCLIDev() *CLI {
+	cli := NewCLIWithColorMode(ColorAuto)
+	cli.MultiLine = true
+	return cli
+}
+
+// SetColorMode sets the color mode of the formatter
+func (c *CLI) SetColorMode(mode ColorMode) {
+	useColors := mode == ColorAlways || (mode == ColorAuto && writer.SupportsColor(os.Stderr))
+	c.NoUseColors = !useColors
+	c.colorMode, c.colorModeSet = mode, true
+}
+
+// ColorMode returns the color mode of the formatter. Formatters created
+// without a color mode are in auto mode unless NoUseColors is set. Only the
+// colors of the formatters in auto mode are adjusted to the writer.
+func (c *CLI) ColorMode() ColorMode {
+	if c.colorModeSet {
+		return c.colorMode
+	}
+	if c.NoUseColors {
+		return ColorNever
+	}
+	return ColorAuto
+}
+
+// Clone returns a copy of the formatter, which can be modified while the
+// formatter is in use
+func (c *CLI) Clone() *CLI {
+	clone := &CLI{
+		NoUseColors:  c.NoUseColors,
+		Theme:        c.Theme,
+		MultiLine:    c.MultiLine,
+		AlignColumns: c.AlignColumns,
+		LabelWidth:   c.LabelWidth,
+		colorMode:    c.ColorMode(),
+		colorModeSet: true,
+	}
+	clone.timestampWidth.Store(c.timestampWidth.Load())
+	return clone
 }
 
 // Format formats the log event data into bytes
 func (c *CLI) Format(event *LogEvent) ([]byte, error) {
+	return c.AppendFormat(make([]byte, 0, 64+len(event.Message)), event)
+}
+
+// AppendFormat appends the formatted log event to dst
+func (c *CLI) AppendFormat(dst []byte, event *LogEvent) ([]byte, error) {
+	rawLabel, _ := event.Metadata["label"].(string)
 	c.colorizeLabel(event)
 
-	buffer := &bytes.Buffer{}
-	buffer.Grow(len(event.Message))
+	buffer := bytes.NewBuffer(dst)
 
-	label, ok := event.Metadata["label"]
+	label, ok := event.Metadata["label"].(string)
 	if label != "" && ok {
 		buffer.WriteRune('[')
 		buffer.WriteString(label)
@@ -35,52 +142,118 @@ func (c *CLI) Format(event *LogEvent) ([]byte, error) {
 		buffer.WriteRune(' ')
 		delete(event.Metadata, "label")
 	}
-	timestamp, ok := event.Metadata["timestamp"]
+	// results printed at the silent level are kept as is
+	align := c.AlignColumns && event.Level != levels.LevelSilent
+	if align {
+		c.pad(buffer, rawLabel, c.labelWidth())
+	}
+	timestamp, ok := event.Metadata["timestamp"].(string)
 	if timestamp != "" && ok {
 		buffer.WriteRune('[')
-		buffer.WriteString(timestamp)
+		buffer.WriteString(c.colorize(c.Theme.Timestamp, timestamp))
 		buffer.WriteRune(']')
 		buffer.WriteRune(' ')
 		delete(event.Metadata, "timestamp")
 	}
-	buffer.WriteString(event.Message)
+	if align {
+		width := int(c.timestampWidth.Load())
+		if len(timestamp) > width {
+			width = len(timestamp)
+			c.timestampWidth.Store(int64(width))
+		}
+		c.pad(buffer, timestamp, width)
+	}
+	caller, ok := event.Metadata["caller"].(string)
+	if caller != "" && ok {
+		buffer.WriteRune('[')
+		buffer.WriteString(caller)
+		buffer.WriteRune(']')
+		buffer.WriteRune(' ')
+		delete(event.Metadata, "caller")
+	}
+	buffer.WriteString(c.colorize(c.Theme.Message, event.Message))
+	// message ids are meant for machine outputs
+	delete(event.Metadata, "msg_id")
 
-	for k, v := range event.Metadata {
+	metadata := flattenMaps(event.Metadata)
+	if c.MultiLine {
+		c.writeMultiLine(buffer, metadata)
+		return buffer.Bytes(), nil
+	}
+	for k, v := range metadata {
 		buffer.WriteRune(' ')
-		buffer.WriteString(c.colorizeKey(k))
+		buffer.WriteString(c.colorize(c.Theme.Key, k))
 		buffer.WriteRune('=')
-		buffer.WriteString(v)
+		buffer.WriteString(stringify(v))
 	}
 	data := buffer.Bytes()
 	return data, nil
 }
 
-// colorizeKey colorizes the metadata key if enabled
-func (c *CLI) colorizeKey(key string) string {
+// writeMultiLine writes the metadata items sorted by key on indented
+// lines, the continuation lines of values being indented as well
+func (c *CLI) writeMultiLine(buffer *bytes.Buffer, metadata map[string]interface{}) {
+	keys := make([]string, 0, len(metadata))
+	width := 0
+	for k := range metadata {
+		keys = append(keys, k)
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	sort.Strings(keys)
+
+	indent := "\n    " + strings.Repeat(" ", width+2)
+	for _, k := range keys {
+		buffer.WriteString("\n    ")
+		buffer.WriteString(c.colorize(c.Theme.Key, k))
+		buffer.WriteString(": ")
+		buffer.WriteString(strings.Repeat(" ", width-len(k)))
+		for i, line := range strings.Split(stringify(metadata[k]), "\n") {
+			if i > 0 {
+				buffer.WriteString(indent)
+			}
+			buffer.WriteString(c.colorize(c.Theme.Value, line))
+		}
+	}
+}
+
+// pad writes the spaces aligning a bracketed column value to width, an
+// empty value being replaced with blanks
+func (c *CLI) pad(buffer *bytes.Buffer, value string, width int) {
+	if width == 0 {
+		return
+	}
+	n := width - len(value)
+	if value == "" {
+		// brackets and separator
+		n += 3
+	}
+	for ; n > 0; n-- {
+		buffer.WriteByte(' ')
+	}
+}
+
+func (c *CLI) labelWidth() int {
+	if c.LabelWidth > 0 {
+		return c.LabelWidth
+	}
+	return 3
+}
+
+// colorize colorizes the string if colors are enabled
+func (c *CLI) colorize(color Color, s string) string {
 	if c.NoUseColors {
-		return key
+		return s
 	}
-	return c.aurora.Bold(key).String()
+	return color.Colorize(s)
 }
 
 // colorizeLabel colorizes the labels if their exists one and colors are enabled
 func (c *CLI) colorizeLabel(event *LogEvent) {
-	label := event.Metadata["label"]
-	if label == "" || c.NoUseColors {
-		return
-	}
-	switch event.Level {
-	case levels.LevelSilent:
+	label, _ := event.Metadata["label"].(string)
+	if label == "" || c.NoUseColors || event.Level == levels.LevelSilent {
 		return
-	case levels.LevelInfo, levels.LevelVerbose:
-		event.Metadata["label"] = c.aurora.Blue(label).String()
-	case levels.LevelFatal:
-		event.Metadata["label"] = c.aurora.Bold(aurora.Red(label)).String()
-	case levels.LevelError:
-		event.Metadata["label"] = c.aurora.Red(label).String()
-	case levels.LevelDebug:
-		event.Metadata["label"] = c.aurora.Magenta(label).String()
-	case levels.LevelWarning:
-		event.Metadata["label"] = c.aurora.Yellow(label).String()
 	}
+	event.Metadata["label"] = c.Theme.Labels[event.Level].Colorize(label)
 }
@@ -0,0 +1,119 @@
+package formatter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultECSVersion is the Elastic Common Schema version of the ECS formatter
+const DefaultECSVersion = "8.11.0"
+
+// ECS is a formatter for outputting Elastic Common Schema compliant json
+// logs, searchable with the standard Kibana dashboards.
+//
+// The event is written with the @timestamp, log.level, message and
+// ecs.version fields. Errors and stack traces are mapped to error.message
+// and error.stack_trace, the caller to log.origin, the trace and span ids to
+// trace.id and span.id, and the other metadata items to labels.*, nested
+// maps being flattened into labels joining the keys with underscores, e.g.
+// labels.http_status.
+type ECS struct {
+	// Version is the ECS version written in ecs.version
+	Version string
+}
+
+var _ Formatter = &ECS{}
+
+// NewECS returns a new ECS formatter
+func NewECS() *ECS {
+	return &ECS{Version: DefaultECSVersion}
+}
+
+// Format formats the log event data into bytes
+func (e *ECS) Format(event *LogEvent) ([]byte, error) {
+	version := e.Version
+	if version == "" {
+		version = DefaultECSVersion
+	}
+	log := map[string]interface{}{"level": event.Level.String()}
+	document := map[string]interface{}{
+		"@timestamp": eventTime(event).UTC().Format("2006-01-02T15:04:05.000Z"),
+		"message":    event.Message,
+		"log":        log,
+		"ecs":        map[string]interface{}{"version": version},
+	}
+	delete(event.Metadata, "label")
+	delete(event.Metadata, "timestamp")
+
+	origin := map[string]interface{}{}
+	errorFields := map[string]interface{}{}
+	labels := map[string]interface{}{}
+	keys := make([]string, 0, len(event.Metadata))
+	for k := range event.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := event.Metadata[k]
+		switch k {
+		case "error":
+			errorFields["message"] = stringify(v)
+		case "stacktrace":
+			errorFields["stack_trace"] = stringify(v)
+		case "caller":
+			origin["file"] = ecsFile(stringify(v))
+		case "function":
+			origin["function"] = stringify(v)
+		case "trace_id":
+			document["trace"] = map[string]interface{}{"id": stringify(v)}
+		case "span_id":
+			document["span"] = map[string]interface{}{"id": stringify(v)}
+		default:
+			addECSLabels(labels, k, v)
+		}
+	}
+	if len(origin) > 0 {
+		log["origin"] = origin
+	}
+	if len(errorFields) > 0 {
+		document["error"] = errorFields
+	}
+	if len(labels) > 0 {
+		document["labels"] = labels
+	}
+	return appendJSONObject(make([]byte, 0, 256+len(event.Message)), document)
+}
+
+// addECSLabels adds the metadata item to the labels, flattening nested maps
+// in key order so that the first item wins when two of them share a label.
+// Labels are keywords whose names must not contain dots.
+func addECSLabels(labels map[string]interface{}, key string, value interface{}) {
+	if child, ok := value.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(child))
+		for k := range child {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			addECSLabels(labels, key+"_"+k, child[k])
+		}
+		return
+	}
+	key = strings.ReplaceAll(key, ".", "_")
+	if _, ok := labels[key]; !ok {
+		labels[key] = stringify(value)
+	}
+}
+
+// ecsFile splits the file:line caller into the log.origin.file fields
+func ecsFile(caller string) map[string]interface{} {
+	file := map[string]interface{}{"name": caller}
+	if i := strings.LastIndexByte(caller, ':'); i > 0 {
+		if line, err := strconv.Atoi(caller[i+1:]); err == nil {
+			file["name"] = caller[:i]
+			file["line"] = line
+		}
+	}
+	return file
+}
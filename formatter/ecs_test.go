@@ -0,0 +1,34 @@
+package formatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestECSNestedLabels(t *testing.T) {
+	event := &LogEvent{
+		Message: "request",
+		Level:   levels.LevelInfo,
+		Time:    time.Unix(1, 0),
+		Metadata: map[string]interface{}{
+			"http": map[string]interface{}{
+				"status": 200,
+				"tls":    map[string]interface{}{"version": "1.3"},
+			},
+			"http_status": 500,
+			"target.host": "example.com",
+		},
+	}
+	data, err := NewECS().Format(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"@timestamp":"1970-01-01T00:00:01.000Z","ecs":{"version":"8.11.0"},` +
+		`"labels":{"http_status":"200","http_tls_version":"1.3","target_host":"example.com"},` +
+		`"log":{"level":"info"},"message":"request"}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
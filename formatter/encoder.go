@@ -0,0 +1,165 @@
+package formatter
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as an escaped json string
+func appendJSONString(buffer []byte, s string) []byte {
+	buffer = append(buffer, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			buffer = append(buffer, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				buffer = append(buffer, '\\', b)
+			case '\n':
+				buffer = append(buffer, '\\', 'n')
+			case '\r':
+				buffer = append(buffer, '\\', 'r')
+			case '\t':
+				buffer = append(buffer, '\\', 't')
+			default:
+				buffer = append(buffer, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buffer = append(buffer, s[start:i]...)
+			buffer = append(buffer, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are invalid in javascript strings
+		if r == '\u2028' || r == '\u2029' {
+			buffer = append(buffer, s[start:i]...)
+			buffer = append(buffer, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buffer = append(buffer, s[start:]...)
+	return append(buffer, '"')
+}
+
+// appendJSONValue appends the json representation of value, falling back
+// to marshalJSONValue for types without a dedicated encoding
+func appendJSONValue(buffer []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buffer, "null"...), nil
+	case string:
+		return appendJSONString(buffer, v), nil
+	case bool:
+		return strconv.AppendBool(buffer, v), nil
+	case int:
+		return strconv.AppendInt(buffer, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(buffer, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(buffer, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(buffer, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(buffer, v, 10), nil
+	case uint:
+		return strconv.AppendUint(buffer, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(buffer, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(buffer, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(buffer, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(buffer, v, 10), nil
+	case float32:
+		return appendJSONFloat(buffer, float64(v), 32), nil
+	case float64:
+		return appendJSONFloat(buffer, v, 64), nil
+	case time.Duration:
+		return strconv.AppendInt(buffer, int64(v), 10), nil
+	case time.Time:
+		return appendJSONString(buffer, v.Format(time.RFC3339Nano)), nil
+	case error:
+		// errors don't carry exported fields, emit their message instead
+		return appendJSONString(buffer, v.Error()), nil
+	case map[string]interface{}:
+		return appendJSONObject(buffer, v)
+	case []interface{}:
+		buffer = append(buffer, '[')
+		for i, item := range v {
+			if i > 0 {
+				buffer = append(buffer, ',')
+			}
+			var err error
+			if buffer, err = appendJSONValue(buffer, item); err != nil {
+				return nil, err
+			}
+		}
+		return append(buffer, ']'), nil
+	case []string:
+		buffer = append(buffer, '[')
+		for i, item := range v {
+			if i > 0 {
+				buffer = append(buffer, ',')
+			}
+			buffer = appendJSONString(buffer, item)
+		}
+		return append(buffer, ']'), nil
+	default:
+		data, err := marshalJSONValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(buffer, data...), nil
+	}
+}
+
+// appendJSONObject appends the map as a json object with sorted keys
+func appendJSONObject(buffer []byte, object map[string]interface{}) ([]byte, error) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buffer = append(buffer, '{')
+	var err error
+	for i, k := range keys {
+		if i > 0 {
+			buffer = append(buffer, ',')
+		}
+		buffer = appendJSONString(buffer, k)
+		buffer = append(buffer, ':')
+		if buffer, err = appendJSONValue(buffer, object[k]); err != nil {
+			return nil, err
+		}
+	}
+	return append(buffer, '}'), nil
+}
+
+// appendJSONFloat appends the float, NaN and infinities are written as
+// strings since json has no representation for them
+func appendJSONFloat(buffer []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendJSONString(buffer, strconv.FormatFloat(f, 'f', -1, bits))
+	}
+	return strconv.AppendFloat(buffer, f, 'f', -1, bits)
+}
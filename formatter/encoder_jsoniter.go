@@ -0,0 +1,12 @@
+//go:build jsoniter
+
+package formatter
+
+import jsoniter "github.com/json-iterator/go"
+
+var jsoniterCfg = jsoniter.Config{SortMapKeys: true}.Froze()
+
+// marshalJSONValue encodes values of types unknown to the json encoder
+func marshalJSONValue(value interface{}) ([]byte, error) {
+	return jsoniterCfg.Marshal(value)
+}
@@ -0,0 +1,10 @@
+//go:build !jsoniter
+
+package formatter
+
+import "encoding/json"
+
+// marshalJSONValue encodes values of types unknown to the json encoder
+func marshalJSONValue(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
@@ -0,0 +1,133 @@
+package formatter
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Column is a column of the FixedWidth formatter
+type Column struct {
+	// Field is the rendered field: "time", "level", "msg" or a metadata key
+	Field string
+	// Width is the width of the column in bytes. A zero width makes the
+	// column take the rest of the line and must only be used last.
+	Width int
+}
+
+// DefaultColumns are the default columns of the FixedWidth formatter
+var DefaultColumns = []Column{
+	{Field: "time", Width: 24},
+	{Field: "level", Width: 3},
+	{Field: "host", Width: 30},
+	{Field: "msg"},
+}
+
+// FixedWidth is a formatter for outputting fixed width columns separated by
+// a space, so that large result files can be paged and cut by byte offsets.
+//
+// Values are padded with spaces or truncated to the column width, line
+// breaks being replaced with spaces. The metadata not rendered in a column
+// is appended as key=value pairs to the last column.
+type FixedWidth struct {
+	Columns []Column
+	// TimeFormat is the layout of the time column, a fixed length UTC
+	// timestamp with milliseconds by default
+	TimeFormat string
+}
+
+var _ Formatter = &FixedWidth{}
+
+// NewFixedWidth returns a new FixedWidth formatter with the columns, the
+// DefaultColumns being used if none is given
+func NewFixedWidth(columns ...Column) *FixedWidth {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	return &FixedWidth{Columns: columns, TimeFormat: "2006-01-02T15:04:05.000Z"}
+}
+
+// Format formats the log event data into bytes
+func (f *FixedWidth) Format(event *LogEvent) ([]byte, error) {
+	columns := f.Columns
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	label, _ := event.Metadata["label"].(string)
+	delete(event.Metadata, "label")
+	delete(event.Metadata, "timestamp")
+
+	buffer := &bytes.Buffer{}
+	buffer.Grow(128 + len(event.Message))
+	for i, column := range columns {
+		if i > 0 {
+			buffer.WriteByte(' ')
+		}
+		var value string
+		switch column.Field {
+		case "time":
+			layout := f.TimeFormat
+			if layout == "" {
+				layout = "2006-01-02T15:04:05.000Z"
+			}
+			value = eventTime(event).UTC().Format(layout)
+		case "level":
+			value = label
+		case "msg":
+			value = event.Message
+		default:
+			value = stringify(event.Metadata[column.Field])
+			delete(event.Metadata, column.Field)
+		}
+		if column.Width <= 0 {
+			buffer.WriteString(singleLine(value))
+			continue
+		}
+		writeFixed(buffer, singleLine(value), column.Width)
+	}
+
+	metadata := flattenMaps(event.Metadata)
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		buffer.WriteByte(' ')
+		buffer.WriteString(plainKey(k))
+		buffer.WriteByte('=')
+		buffer.WriteString(plainQuote(stringify(metadata[k])))
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeFixed writes the value truncated or padded to exactly width bytes,
+// without splitting multi-byte characters
+func writeFixed(buffer *bytes.Buffer, value string, width int) {
+	if len(value) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		value = value[:cut]
+	}
+	buffer.WriteString(value)
+	for i := len(value); i < width; i++ {
+		buffer.WriteByte(' ')
+	}
+}
+
+// singleLine replaces the line breaks and tabs of the value with spaces
+func singleLine(value string) string {
+	if !strings.ContainsAny(value, "\r\n\t") {
+		return value
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\r', '\n', '\t':
+			return ' '
+		}
+		return r
+	}, value)
+}
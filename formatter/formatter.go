@@ -1,6 +1,12 @@
 package formatter
 
-import "github.com/projectdiscovery/gologger/levels"
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
 
 // Formatter type format raw logging data into something useful
 type Formatter interface {
@@ -8,9 +14,86 @@ type Formatter interface {
 	Format(event *LogEvent) ([]byte, error)
 }
 
+// Appender is implemented by formatters able to format the event into a
+// caller provided buffer, which lets the logger recycle the buffers
+type Appender interface {
+	// AppendFormat appends the formatted event to dst and returns the
+	// extended buffer
+	AppendFormat(dst []byte, event *LogEvent) ([]byte, error)
+}
+
 // LogEvent is the respresentation of a single event to be logged.
 type LogEvent struct {
 	Message  string
 	Level    levels.Level
-	Metadata map[string]string
+	Metadata map[string]interface{}
+	// Time is the time the event was created, which may differ from the
+	// time it is formatted or written for buffered outputs
+	Time time.Time
+}
+
+// eventTime returns the event time falling back to the current time
+func eventTime(event *LogEvent) time.Time {
+	if event.Time.IsZero() {
+		return time.Now()
+	}
+	return event.Time
+}
+
+// stringify returns the textual representation of a metadata value
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case time.Duration:
+		return v.String()
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// flattenMaps returns the metadata with the nested maps expanded into
+// dotted keys (e.g. "http.status"), or the metadata itself without maps
+func flattenMaps(metadata map[string]interface{}) map[string]interface{} {
+	nested := false
+	for _, v := range metadata {
+		if _, ok := v.(map[string]interface{}); ok {
+			nested = true
+			break
+		}
+	}
+	if !nested {
+		return metadata
+	}
+	flat := make(map[string]interface{}, len(metadata))
+	flattenInto(flat, "", metadata)
+	return flat
+}
+
+func flattenInto(flat map[string]interface{}, prefix string, metadata map[string]interface{}) {
+	for k, v := range metadata {
+		if child, ok := v.(map[string]interface{}); ok {
+			flattenInto(flat, prefix+k+".", child)
+			continue
+		}
+		flat[prefix+k] = v
+	}
 }
@@ -0,0 +1,56 @@
+package formatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// newBenchEvent returns a typical event, formatters consuming the metadata
+func newBenchEvent() *LogEvent {
+	return &LogEvent{
+		Message: "request completed",
+		Level:   levels.LevelInfo,
+		Time:    time.Unix(1700000000, 0),
+		Metadata: map[string]interface{}{
+			"label":    "INF",
+			"host":     "example.com",
+			"port":     443,
+			"duration": 125 * time.Millisecond,
+		},
+	}
+}
+
+func BenchmarkCLIFormat(b *testing.B) {
+	formatter := NewCLI(true)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := formatter.Format(newBenchEvent()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONFormat(b *testing.B) {
+	formatter := &JSON{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := formatter.Format(newBenchEvent()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONAppendFormat(b *testing.B) {
+	formatter := &JSON{}
+	buffer := make([]byte, 0, 512)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, err := formatter.AppendFormat(buffer[:0], newBenchEvent())
+		if err != nil {
+			b.Fatal(err)
+		}
+		buffer = data
+	}
+}
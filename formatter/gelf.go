@@ -0,0 +1,141 @@
+package formatter
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// GELF is a formatter for outputting GELF 1.1 messages for Graylog.
+//
+// The first line of the message is used as short_message and the whole
+// message as full_message when it spans multiple lines. Metadata items are
+// written as additional fields prefixed with an underscore, nested maps being
+// flattened into fields joining the keys with underscores, e.g. _http_status.
+// As additional fields can only be strings or numbers, the other values are
+// written as JSON strings.
+type GELF struct {
+	// Host is the name of the host sending the messages
+	Host string
+}
+
+var _ Formatter = &GELF{}
+
+// gelfLevels maps the levels to syslog severities
+var gelfLevels = map[levels.Level]int{
+	levels.LevelFatal:   2, // critical
+	levels.LevelSilent:  5, // notice
+	levels.LevelError:   3, // error
+	levels.LevelInfo:    6, // informational
+	levels.LevelWarning: 4, // warning
+	levels.LevelDebug:   7, // debug
+	levels.LevelVerbose: 7, // debug
+	levels.LevelTrace:   7, // debug
+	levels.LevelAudit:   5, // notice
+}
+
+// NewGELF returns a new GELF formatter using the machine hostname as host
+func NewGELF() *GELF {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	return &GELF{Host: host}
+}
+
+// Format formats the log event data into bytes
+func (g *GELF) Format(event *LogEvent) ([]byte, error) {
+	buffer := make([]byte, 0, 128+len(event.Message))
+
+	shortMessage := event.Message
+	if i := strings.IndexByte(shortMessage, '\n'); i >= 0 {
+		shortMessage = shortMessage[:i]
+	}
+	if shortMessage == "" {
+		// short_message is mandatory and must not be empty
+		shortMessage = "-"
+	}
+	severity, ok := gelfLevels[event.Level]
+	if !ok {
+		severity = 6
+	}
+	t := eventTime(event)
+
+	buffer = append(buffer, `{"version":"1.1","host":`...)
+	buffer = appendJSONString(buffer, g.Host)
+	buffer = append(buffer, `,"short_message":`...)
+	buffer = appendJSONString(buffer, shortMessage)
+	if strings.IndexByte(event.Message, '\n') >= 0 {
+		buffer = append(buffer, `,"full_message":`...)
+		buffer = appendJSONString(buffer, event.Message)
+	}
+	buffer = append(buffer, `,"timestamp":`...)
+	buffer = appendJSONFloat(buffer, float64(t.UnixNano())/1e9, 64)
+	buffer = append(buffer, `,"level":`...)
+	buffer = strconv.AppendInt(buffer, int64(severity), 10)
+
+	delete(event.Metadata, "timestamp")
+	fields := make(map[string]interface{}, len(event.Metadata))
+	flattenGELFFields(fields, "", event.Metadata)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		buffer = append(buffer, ',')
+		buffer = appendJSONString(buffer, k)
+		buffer = append(buffer, ':')
+		value, err := appendJSONValue(nil, fields[k])
+		if err != nil {
+			return nil, err
+		}
+		if len(value) > 0 && (value[0] == '"' || value[0] == '-' || (value[0] >= '0' && value[0] <= '9')) {
+			buffer = append(buffer, value...)
+		} else {
+			buffer = appendJSONString(buffer, string(value))
+		}
+	}
+	buffer = append(buffer, '}')
+	return buffer, nil
+}
+
+// flattenGELFFields adds the metadata items to the fields with their
+// additional field names, flattening the nested maps. Keys are visited in
+// order so that the first item wins when two of them share a field name.
+func flattenGELFFields(fields map[string]interface{}, prefix string, metadata map[string]interface{}) {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if child, ok := metadata[k].(map[string]interface{}); ok {
+			flattenGELFFields(fields, prefix+k+"_", child)
+			continue
+		}
+		name := gelfFieldName(prefix + k)
+		if _, ok := fields[name]; !ok {
+			fields[name] = metadata[k]
+		}
+	}
+}
+
+// gelfFieldName returns the additional field name for the metadata key,
+// replacing the characters not allowed by the specification. The reserved
+// _id field is renamed to _id_.
+func gelfFieldName(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+	if key == "id" {
+		return "_id_"
+	}
+	return "_" + key
+}
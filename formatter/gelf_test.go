@@ -0,0 +1,68 @@
+package formatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestGELFFormat(t *testing.T) {
+	g := &GELF{Host: "scanner-1"}
+	event := &LogEvent{
+		Message: "request failed\nconnection reset by peer",
+		Level:   levels.LevelError,
+		Time:    time.Unix(1700000000, 500000000),
+		Metadata: map[string]interface{}{
+			"timestamp":   "ignored",
+			"host":        "example.com",
+			"id":          "abc",
+			"status code": 502,
+		},
+	}
+	data, err := g.Format(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":"1.1","host":"scanner-1","short_message":"request failed",` +
+		`"full_message":"request failed\nconnection reset by peer","timestamp":1700000000.5,"level":3,` +
+		`"_host":"example.com","_id_":"abc","_status_code":502}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
+
+func TestGELFShortMessage(t *testing.T) {
+	data, err := (&GELF{Host: "h"}).Format(&LogEvent{Level: levels.LevelDebug, Time: time.Unix(1, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"version":"1.1","host":"h","short_message":"-","timestamp":1,"level":7}`; string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
+
+func TestGELFNestedFields(t *testing.T) {
+	event := &LogEvent{
+		Message: "request",
+		Level:   levels.LevelInfo,
+		Time:    time.Unix(1, 0),
+		Metadata: map[string]interface{}{
+			"http": map[string]interface{}{
+				"status": 200,
+				"tls":    map[string]interface{}{"version": "1.3"},
+			},
+			"cached": true,
+			"ports":  []int{80, 443},
+		},
+	}
+	data, err := (&GELF{Host: "h"}).Format(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":"1.1","host":"h","short_message":"request","timestamp":1,"level":6,` +
+		`"_cached":"true","_http_status":200,"_http_tls_version":"1.3","_ports":"[80,443]"}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+}
@@ -1,35 +1,244 @@
 package formatter
 
 import (
-	"time"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// JSON is a formatter for outputting json logs.
+//
+// The timestamp, level and msg keys are always written first followed by the
+// metadata keys in sorted order. Metadata values keep their native types.
+// The standard keys can be renamed with the JSONOptions.
+type JSON struct {
+	JSONOptions
+	// NestDottedKeys renders dotted metadata keys (e.g. "http.status") as
+	// nested objects instead of flat keys.
+	NestDottedKeys bool
+	// ANSI defines how escape sequences embedded in the message and string
+	// metadata values are handled, they are kept by default
+	ANSI ANSIPolicy
+
+	ansiEvents atomic.Uint64
+}
+
+// ANSIPolicy defines how the JSON formatter handles ANSI escape sequences,
+// usually colors embedded in messages destined for machine output
+type ANSIPolicy int
 
-	jsoniter "github.com/json-iterator/go"
+// Available ANSI policies
+const (
+	// ANSIKeep keeps the escape sequences
+	ANSIKeep ANSIPolicy = iota
+	// ANSIStrip silently removes the escape sequences
+	ANSIStrip
+	// ANSIFlag removes the escape sequences and adds "ansi_stripped": true
+	// to the event, so that the offending call sites can be found
+	ANSIFlag
+	// ANSIReject fails formatting events with escape sequences
+	ANSIReject
 )
 
-// JSON is a formatter for outputting json logs
-type JSON struct{}
+// ErrANSI is returned by the JSON formatter rejecting events with escape sequences
+var ErrANSI = errors.New("event contains ANSI escape sequences")
 
-var _ Formatter = &JSON{}
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
 
-var jsoniterCfg jsoniter.API
+var (
+	_ Formatter = &JSON{}
+	_ Appender  = &JSON{}
+)
 
-func init() {
-	jsoniterCfg = jsoniter.Config{SortMapKeys: true}.Froze()
+// JSONOptions renames the standard keys of the JSON formatter so that the
+// output matches a schema (ECS, Datadog, ...). Empty options keep the
+// default keys.
+type JSONOptions struct {
+	// TimestampKey is the key of the event time, "timestamp" by default
+	TimestampKey string
+	// TimeFormat is the layout of the event time, ISO 8601 with a numeric
+	// zone by default
+	TimeFormat string
+	// MessageKey is the key of the message, "msg" by default
+	MessageKey string
+	// LabelKey is the key of the event label (e.g. "INF"), "level" by default
+	LabelKey string
+	// LevelKey is the key of the lowercase level name (e.g. "info"). The
+	// level name isn't written when empty, and replaces the label when both
+	// keys are the same.
+	LevelKey string
+}
+
+// NewJSON returns a new JSON formatter with the options
+func NewJSON(options JSONOptions) *JSON {
+	return &JSON{JSONOptions: options}
+}
+
+// keys returns the standard keys with the defaults applied
+func (o JSONOptions) keys() (timestamp, message, label string) {
+	timestamp, message, label = o.TimestampKey, o.MessageKey, o.LabelKey
+	if timestamp == "" {
+		timestamp = "timestamp"
+	}
+	if message == "" {
+		message = "msg"
+	}
+	if label == "" {
+		label = "level"
+	}
+	if label == o.LevelKey {
+		label = ""
+	}
+	return timestamp, message, label
 }
 
 // Format formats the log event data into bytes
 func (j *JSON) Format(event *LogEvent) ([]byte, error) {
-	data := make(map[string]interface{})
-	if label, ok := event.Metadata["label"]; ok {
-		if label != "" {
-			data["level"] = label
-			delete(event.Metadata, "label")
+	return j.AppendFormat(make([]byte, 0, 128+len(event.Message)), event)
+}
+
+// AppendFormat appends the formatted log event to dst
+func (j *JSON) AppendFormat(dst []byte, event *LogEvent) ([]byte, error) {
+	if j.ANSI != ANSIKeep && j.stripANSI(event) {
+		j.ansiEvents.Add(1)
+		switch j.ANSI {
+		case ANSIReject:
+			return nil, ErrANSI
+		case ANSIFlag:
+			event.Metadata["ansi_stripped"] = true
+		}
+	}
+	timestampKey, messageKey, labelKey := j.keys()
+	timeFormat := j.TimeFormat
+	if timeFormat == "" {
+		timeFormat = "2006-01-02T15:04:05-0700"
+	}
+	buffer := append(dst, '{')
+	buffer = appendJSONString(buffer, timestampKey)
+	buffer = append(buffer, ':')
+	buffer = appendJSONString(buffer, eventTime(event).UTC().Format(timeFormat))
+	if j.LevelKey != "" {
+		buffer = append(buffer, ',')
+		buffer = appendJSONString(buffer, j.LevelKey)
+		buffer = append(buffer, ':')
+		buffer = appendJSONString(buffer, event.Level.String())
+	}
+	hasLabel := false
+	if label, ok := event.Metadata["label"].(string); ok {
+		if label != "" && labelKey != "" {
+			hasLabel = true
+			buffer = append(buffer, ',')
+			buffer = appendJSONString(buffer, labelKey)
+			buffer = append(buffer, ':')
+			buffer = appendJSONString(buffer, label)
+		}
+		delete(event.Metadata, "label")
+	}
+	buffer = append(buffer, ',')
+	buffer = appendJSONString(buffer, messageKey)
+	buffer = append(buffer, ':')
+	buffer = appendJSONString(buffer, event.Message)
+
+	metadata := event.Metadata
+	if j.NestDottedKeys {
+		metadata = nestDottedKeys(metadata)
+	}
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		// the timestamp metadata duplicates the event time
+		if k == "timestamp" || k == timestampKey || k == messageKey || k == j.LevelKey || (k == labelKey && hasLabel) {
+			continue
 		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var err error
+	for _, k := range keys {
+		buffer = append(buffer, ',')
+		buffer = appendJSONString(buffer, k)
+		buffer = append(buffer, ':')
+		if buffer, err = appendJSONValue(buffer, metadata[k]); err != nil {
+			return nil, err
+		}
+	}
+	buffer = append(buffer, '}')
+	return buffer, nil
+}
+
+// jsonValue converts values without a useful json representation
+func jsonValue(value interface{}) interface{} {
+	// errors don't carry exported fields, emit their message instead
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	return value
+}
+
+// nestDottedKeys returns a copy of metadata with dotted keys expanded into
+// nested maps. When a key is both a value and the prefix of other keys (e.g.
+// "a" and "a.b"), the value is kept under "a" and the other keys are left
+// flat. Keys are processed in sorted order so that the result is stable.
+func nestDottedKeys(metadata map[string]interface{}) map[string]interface{} {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	// a value sorts before the keys it prefixes
+	sort.Strings(keys)
+
+	nested := make(map[string]interface{}, len(metadata))
+	// created holds the prefixes of the maps created here, the other maps
+	// being metadata values which must not be modified
+	created := make(map[string]bool)
+	for _, k := range keys {
+		v := jsonValue(metadata[k])
+		parts := strings.Split(k, ".")
+		current := nested
+		for i, part := range parts[:len(parts)-1] {
+			prefix := strings.Join(parts[:i+1], ".")
+			if existing, ok := current[part]; ok {
+				if !created[prefix] {
+					current = nil
+					break
+				}
+				current = existing.(map[string]interface{})
+				continue
+			}
+			child := make(map[string]interface{})
+			current[part] = child
+			created[prefix] = true
+			current = child
+		}
+		if current == nil {
+			nested[k] = v
+			continue
+		}
+		current[parts[len(parts)-1]] = v
+	}
+	return nested
+}
+
+// ANSIEvents returns the number of events found with ANSI escape sequences
+// when the ANSI policy is not ANSIKeep
+func (j *JSON) ANSIEvents() uint64 {
+	return j.ansiEvents.Load()
+}
+
+// stripANSI removes the escape sequences from the message and the string
+// metadata values, returning true if any was found
+func (j *JSON) stripANSI(event *LogEvent) bool {
+	found := false
+	if strings.Contains(event.Message, "\x1b[") {
+		event.Message = ansiRegex.ReplaceAllString(event.Message, "")
+		found = true
 	}
 	for k, v := range event.Metadata {
-		data[k] = v
+		if s, ok := v.(string); ok && strings.Contains(s, "\x1b[") {
+			event.Metadata[k] = ansiRegex.ReplaceAllString(s, "")
+			found = true
+		}
 	}
-	data["msg"] = event.Message
-	data["timestamp"] = time.Now().UTC().Format("2006-01-02T15:04:05-0700")
-	return jsoniterCfg.Marshal(data)
+	return found
 }
@@ -0,0 +1,41 @@
+package formatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestJSONNestDottedKeys(t *testing.T) {
+	j := &JSON{NestDottedKeys: true}
+	user := map[string]interface{}{"id": 1}
+	want := `{"timestamp":"2023-11-14T22:13:20+0000","msg":"request",` +
+		`"a":1,"a.b":2,"a.b.c":3,"http":{"method":"GET","status":200},"user":{"id":1},"user.name":"admin"}`
+	// the result must not depend on the map iteration order
+	for i := 0; i < 20; i++ {
+		data, err := j.Format(&LogEvent{
+			Message: "request",
+			Level:   levels.LevelInfo,
+			Time:    time.Unix(1700000000, 0),
+			Metadata: map[string]interface{}{
+				"a":           1,
+				"a.b":         2,
+				"a.b.c":       3,
+				"http.status": 200,
+				"http.method": "GET",
+				"user":        user,
+				"user.name":   "admin",
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Fatalf("got  %s\nwant %s", data, want)
+		}
+	}
+	if len(user) != 1 {
+		t.Errorf("metadata map modified: %v", user)
+	}
+}
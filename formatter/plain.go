@@ -0,0 +1,121 @@
+package formatter
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Plain is a formatter for outputting single line, space delimited logs
+// without colors, suited for awk/grep post-processing.
+//
+// Lines are made of the timestamp (if any), the label ("-" if none), the
+// message and the metadata as key=value pairs sorted by key. The message
+// and values containing spaces, quotes or special characters are single
+// quoted for the shell, the values containing non printable characters
+// such as new lines being ANSI-C quoted ($'...') so that every line stays
+// a single line.
+type Plain struct{}
+
+var _ Formatter = &Plain{}
+
+// NewPlain returns a new Plain formatter
+func NewPlain() *Plain {
+	return &Plain{}
+}
+
+// Format formats the log event data into bytes
+func (p *Plain) Format(event *LogEvent) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.Grow(len(event.Message))
+
+	if timestamp, ok := event.Metadata["timestamp"].(string); ok && timestamp != "" {
+		buffer.WriteString(plainQuote(timestamp))
+		buffer.WriteRune(' ')
+	}
+	delete(event.Metadata, "timestamp")
+
+	label, _ := event.Metadata["label"].(string)
+	if label == "" {
+		label = "-"
+	}
+	buffer.WriteString(plainQuote(label))
+	delete(event.Metadata, "label")
+
+	buffer.WriteRune(' ')
+	buffer.WriteString(plainQuote(event.Message))
+
+	metadata := flattenMaps(event.Metadata)
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		buffer.WriteRune(' ')
+		buffer.WriteString(plainKey(k))
+		buffer.WriteRune('=')
+		buffer.WriteString(plainQuote(stringify(metadata[k])))
+	}
+	return buffer.Bytes(), nil
+}
+
+// plainQuote quotes the value if it isn't safe to be split on spaces or
+// used unquoted in a shell
+func plainQuote(value string) string {
+	if value == "" {
+		return "''"
+	}
+	if !utf8.ValidString(value) || strings.IndexFunc(value, isNotPrint) >= 0 {
+		return ansiQuote(value)
+	}
+	if strings.IndexFunc(value, needsPlainQuoting) < 0 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// ansiQuote quotes the value with the ANSI-C quoting of the shells, whose
+// escape sequences match the Go ones
+func ansiQuote(value string) string {
+	var b strings.Builder
+	b.WriteString("$'")
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteString(`\x`)
+			b.WriteByte(hexDigits[value[i]>>4])
+			b.WriteByte(hexDigits[value[i]&0xf])
+		} else {
+			quoted := strconv.QuoteRune(r)
+			b.WriteString(quoted[1 : len(quoted)-1])
+		}
+		i += size
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+func isNotPrint(r rune) bool {
+	return !unicode.IsPrint(r)
+}
+
+func needsPlainQuoting(r rune) bool {
+	if unicode.IsSpace(r) || !unicode.IsPrint(r) {
+		return true
+	}
+	return strings.ContainsRune("\"'`\\$&|;<>()*?[]{}#~!=", r)
+}
+
+// plainKey replaces the characters which would break key=value parsing
+func plainKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '=' || needsPlainQuoting(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
@@ -0,0 +1,47 @@
+package formatter
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestPlainQuote(t *testing.T) {
+	tests := []struct {
+		value  string
+		quoted string
+	}{
+		{"", "''"},
+		{"simple", "simple"},
+		{"https://example.com/path", "https://example.com/path"},
+		{"two words", "'two words'"},
+		{"it's", `'it'\''s'`},
+		{`say "hi"`, `'say "hi"'`},
+		{"$HOME `id` \\", "'$HOME `id` \\'"},
+		{"line\nbreak", `$'line\nbreak'`},
+		{"tab\tand 'quote'", `$'tab\tand \'quote\''`},
+		{"bell\a", `$'bell\a'`},
+		{"invalid \xff", `$'invalid \xff'`},
+		{"unicode é", "'unicode é'"},
+	}
+	for _, test := range tests {
+		if got := plainQuote(test.value); got != test.quoted {
+			t.Errorf("plainQuote(%q) = %s, want %s", test.value, got, test.quoted)
+		}
+	}
+}
+
+func TestPlainQuoteShell(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not found")
+	}
+	for _, value := range []string{"it's a 'test'", "$HOME `id` \\ \"x\"", "line\nbreak\ttab 'q'", "invalid \xff"} {
+		output, err := exec.Command(bash, "-c", "printf %s "+plainQuote(value)).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(output) != value {
+			t.Errorf("shell unquoted %q as %q", value, output)
+		}
+	}
+}
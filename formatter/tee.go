@@ -27,7 +27,7 @@ func (tee *Tee) Format(event *LogEvent) (bts []byte, err error) {
 	if event == nil {
 		return
 	}
-	label := event.Metadata["label"]
+	label, _ := event.Metadata["label"].(string)
 
 	bts, err = tee.Formatter.Format(event)
 	// the format delete the label key from Metadat - if we want colors we need to add it again
@@ -0,0 +1,70 @@
+package formatter
+
+import "github.com/projectdiscovery/gologger/levels"
+
+// Color is an ANSI SGR color sequence parameter (e.g. "1;31" for bold red)
+type Color string
+
+// Available colors, which can be combined with Combine
+const (
+	ColorNone    Color = ""
+	ColorBold    Color = "1"
+	ColorRed     Color = "31"
+	ColorGreen   Color = "32"
+	ColorYellow  Color = "33"
+	ColorBlue    Color = "34"
+	ColorMagenta Color = "35"
+	ColorCyan    Color = "36"
+	ColorWhite   Color = "37"
+	ColorGray    Color = "90"
+)
+
+// Combine returns the combination of the colors
+func Combine(colors ...Color) Color {
+	var combined Color
+	for _, color := range colors {
+		if color == ColorNone {
+			continue
+		}
+		if combined != ColorNone {
+			combined += ";"
+		}
+		combined += color
+	}
+	return combined
+}
+
+// Colorize wraps s in the escape sequences of the color
+func (c Color) Colorize(s string) string {
+	if c == ColorNone || s == "" {
+		return s
+	}
+	return "\x1b[" + string(c) + "m" + s + "\x1b[0m"
+}
+
+// ColorTheme defines the colors used by the CLI formatter
+type ColorTheme struct {
+	// Labels is the color of the label per level
+	Labels    map[levels.Level]Color
+	Key       Color
+	Message   Color
+	Timestamp Color
+	// Value is the color of the metadata values in multi-line mode
+	Value Color
+}
+
+// DefaultColorTheme is the theme used by the CLI formatter by default
+var DefaultColorTheme = ColorTheme{
+	Labels: map[levels.Level]Color{
+		levels.LevelFatal:   Combine(ColorBold, ColorRed),
+		levels.LevelError:   ColorRed,
+		levels.LevelInfo:    ColorBlue,
+		levels.LevelWarning: ColorYellow,
+		levels.LevelDebug:   ColorMagenta,
+		levels.LevelVerbose: ColorBlue,
+		levels.LevelTrace:   ColorGray,
+		levels.LevelAudit:   ColorGreen,
+	},
+	Key:   ColorBold,
+	Value: ColorCyan,
+}
@@ -0,0 +1,128 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// XML is a formatter for outputting one XML element per event, for log
+// pipelines requiring XML records.
+//
+// The event time and label are written as attributes of the record element
+// followed by the message element. Metadata items are written as attributes
+// or as child elements, keys being sanitized into valid XML names. Since
+// events are formatted one by one, the root element wrapping the records is
+// available with Header and Footer for writers producing whole documents.
+type XML struct {
+	// RootElement is the name of the root element, "log" by default
+	RootElement string
+	// RecordElement is the name of the event elements, "event" by default
+	RecordElement string
+	// MetadataAsAttributes writes the metadata as attributes of the record
+	// element instead of child elements
+	MetadataAsAttributes bool
+}
+
+var _ Formatter = &XML{}
+
+// NewXML returns a new XML formatter with the default element names
+func NewXML() *XML {
+	return &XML{}
+}
+
+// Header returns the XML declaration and the opening root element
+func (x *XML) Header() []byte {
+	return []byte(xml.Header + "<" + x.rootElement() + ">")
+}
+
+// Footer returns the closing root element
+func (x *XML) Footer() []byte {
+	return []byte("</" + x.rootElement() + ">")
+}
+
+// Format formats the log event data into bytes
+func (x *XML) Format(event *LogEvent) ([]byte, error) {
+	record := x.RecordElement
+	if record == "" {
+		record = "event"
+	}
+	record = xmlName(record)
+
+	label, _ := event.Metadata["label"].(string)
+	delete(event.Metadata, "label")
+	delete(event.Metadata, "timestamp")
+
+	metadata := flattenMaps(event.Metadata)
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buffer := &bytes.Buffer{}
+	buffer.Grow(128 + len(event.Message))
+	buffer.WriteString("<" + record)
+	writeXMLAttr(buffer, "time", eventTime(event).UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	writeXMLAttr(buffer, "level", event.Level.String())
+	if label != "" {
+		writeXMLAttr(buffer, "label", label)
+	}
+	if x.MetadataAsAttributes {
+		for _, k := range keys {
+			name := xmlName(k)
+			// avoid duplicating the attributes of the record
+			if name == "time" || name == "level" || name == "label" {
+				name = "_" + name
+			}
+			writeXMLAttr(buffer, name, stringify(metadata[k]))
+		}
+	}
+	buffer.WriteString("><message>")
+	_ = xml.EscapeText(buffer, []byte(event.Message))
+	buffer.WriteString("</message>")
+	if !x.MetadataAsAttributes {
+		for _, k := range keys {
+			name := xmlName(k)
+			buffer.WriteString("<" + name + ">")
+			_ = xml.EscapeText(buffer, []byte(stringify(metadata[k])))
+			buffer.WriteString("</" + name + ">")
+		}
+	}
+	buffer.WriteString("</" + record + ">")
+	return buffer.Bytes(), nil
+}
+
+func (x *XML) rootElement() string {
+	if x.RootElement == "" {
+		return "log"
+	}
+	return xmlName(x.RootElement)
+}
+
+func writeXMLAttr(buffer *bytes.Buffer, name, value string) {
+	buffer.WriteString(" " + name + `="`)
+	_ = xml.EscapeText(buffer, []byte(value))
+	buffer.WriteByte('"')
+}
+
+// xmlName replaces the characters not allowed in XML names with
+// underscores, prefixing names not starting with a letter or underscore
+func xmlName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+	if sanitized == "" {
+		return "_"
+	}
+	first := []rune(sanitized)[0]
+	if (!unicode.IsLetter(first) && first != '_') || strings.HasPrefix(strings.ToLower(sanitized), "xml") {
+		sanitized = "_" + sanitized
+	}
+	return sanitized
+}
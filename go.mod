@@ -4,22 +4,13 @@ go 1.21
 
 require (
 	github.com/json-iterator/go v1.1.12
-	github.com/logrusorgru/aurora v2.0.3+incompatible
-	github.com/mholt/archiver/v3 v3.5.1
+	github.com/klauspost/compress v1.17.4
 	github.com/projectdiscovery/utils v0.4.5
 	gopkg.in/djherbis/times.v1 v1.3.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/andybalholm/brotli v1.0.6 // indirect
-	github.com/dsnet/compress v0.0.2-0.20210315054119-f66993602bf5 // indirect
-	github.com/golang/snappy v0.0.4 // indirect
-	github.com/klauspost/compress v1.17.4 // indirect
-	github.com/klauspost/pgzip v1.2.5 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
-	github.com/nwaples/rardecode v1.1.3 // indirect
-	github.com/pierrec/lz4/v4 v4.1.2 // indirect
-	github.com/ulikunitz/xz v0.5.11 // indirect
-	github.com/xi2/xz v0.0.0-20171230120015-48954b6210f8 // indirect
 )
@@ -2,8 +2,12 @@ package gologger
 
 import (
 	"fmt"
-	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/projectdiscovery/gologger/formatter"
@@ -19,6 +23,8 @@ var (
 		levels.LevelWarning: "WRN",
 		levels.LevelDebug:   "DBG",
 		levels.LevelVerbose: "VER",
+		levels.LevelTrace:   "TRC",
+		levels.LevelAudit:   "AUD",
 	}
 	// DefaultLogger is the default logging instance
 	DefaultLogger *Logger
@@ -33,47 +39,293 @@ func init() {
 
 // Logger is a logger for logging structured data in a beautfiul and fast manner.
 type Logger struct {
+	// outputMutex protects the writer, formatter and sinks, which can be
+	// changed while events are logged from other goroutines
+	outputMutex       sync.RWMutex
 	writer            writer.Writer
-	maxLevel          levels.Level
+	maxLevel          atomic.Int64
 	formatter         formatter.Formatter
 	timestampMinLevel levels.Level
 	timestamp         bool
+	batchMutex        sync.Mutex
+	sinks             []*Sink
+	stackTraces       bool
+	callerMinLevel    levels.Level
+	callerInfo        bool
+	counters          counters
+	labelCounters     labelCounters
+	fields            map[string]interface{}
+	eventIDs          bool
+	idGenerator       IDGenerator
+	sequence          atomic.Uint64
+	hooks             []Hook
+	exitCodes         map[string]int
+	samplers          map[levels.Level]Sampler
+	suppressed        counters
+	interner          *interner
+	fieldCapacity     int
+	deduplicator      *deduplicator
+	exitFunc          func(code int)
+	fatalPolicy       FatalPolicy
+	exitHooks         []func()
+	exitHookTimeout   time.Duration
+	exitHookErrors    func(err error)
+	rateLimiter       *rateLimiter
+	rateDropped       atomic.Uint64
+	writeErrors       atomic.Uint64
+	redactors         []Redactor
+	streamPolicy      StreamPolicy
+	segments          atomic.Uint64
+	verbosity         atomic.Int64
+	bootstrap         atomic.Pointer[bootstrap]
+	translator        Translator
+	filter            Filter
+	rollup            atomic.Pointer[rollup]
+	// parent and component are set on named loggers, see Named, and on
+	// the loggers returned by PushLabel
+	parent      *Logger
+	component   string
+	levelSet    atomic.Bool
+	pushedLabel string
+	labelPushed bool
 }
 
 // Log logs a message to a logger instance
 func (l *Logger) Log(event *Event) {
+	if l.parent != nil {
+		// named loggers only carry a level, events are logged by the parent
+		if event.named == nil {
+			event.named = l
+		}
+		l.parent.Log(event)
+		return
+	}
+	if debugMode && event.emitted {
+		reportDebug(fmt.Sprintf("gologger: event emitted twice, events must not be reused after Msg\n%s", captureStackTrace(3)))
+	}
+	event.emitted = true
+	l.log(event)
+	releaseEvent(event)
+}
+
+// log filters, formats and writes the event
+func (l *Logger) log(event *Event) {
+	if l.bootstrapping(event) {
+		return
+	}
 	if !isCurrentLevelEnabled(event) {
 		return
 	}
+	if !event.forced && !l.sample(event) {
+		return
+	}
 	event.message = strings.TrimSuffix(event.message, "\n")
-	data, err := l.formatter.Format(&formatter.LogEvent{
-		Message:  event.message,
-		Level:    event.level,
-		Metadata: event.metadata,
-	})
-	if err != nil {
+	if !event.forced && l.deduplicator != nil && !l.deduplicator.allow(event) {
 		return
 	}
-	l.writer.Write(data, event.level)
+	if !event.forced && event.level != levels.LevelFatal && l.rateLimiter != nil && !l.rateLimiter.allow() {
+		l.rateDropped.Add(1)
+		l.recordDropped(event)
+		return
+	}
+	event.resolveLazy()
+	l.applyPushedLabel(event)
+	if l.filtered(event) {
+		return
+	}
+	l.translate(event)
+	l.redact(event)
+	if !l.runBeforeFormat(event) {
+		return
+	}
+	exitCode := 0
+	if event.level == levels.LevelFatal {
+		exitCode = l.exitCode(event)
+	}
+	l.counters.inc(event.level)
+	if label, ok := event.metadata["label"].(string); ok && label != "" {
+		l.labelCounters.inc(label)
+	}
+	if l.eventIDs {
+		if l.idGenerator != nil {
+			event.metadata["event_id"] = l.idGenerator(event)
+		} else {
+			event.metadata["event_id"] = l.eventID(event)
+		}
+	}
+
+	// formatters consume the metadata so each sink needs its own copy
+	sinks := l.loadSinks()
+	shared := len(sinks) > 0
+	f, w := l.output()
+	written, buffer := l.emit(f, w, event, event.maxLevel(), shared)
+	for _, sink := range sinks {
+		data, sinkBuffer := l.emit(l.sinkFormatter(sink), sink.Writer, event, sink.MaxLevel, shared)
+		if written == nil {
+			written, buffer = data, sinkBuffer
+		} else if sinkBuffer != nil {
+			releaseBuffer(sinkBuffer, data)
+		}
+	}
+	// hooks run once per event with the first written line
+	if written != nil {
+		l.runAfterWrite(event, written)
+	}
+	if buffer != nil {
+		releaseBuffer(buffer, written)
+	}
 
 	if event.level == levels.LevelFatal {
-		os.Exit(1)
+		l.exit(exitCode, event.message)
+	}
+}
+
+// emit writes the event to the writer if its level is enabled up to maxLevel,
+// see write for the returned values
+func (l *Logger) emit(f formatter.Formatter, w writer.Writer, event *Event, maxLevel levels.Level, copyMetadata bool) ([]byte, *[]byte) {
+	level, transient := event.level, false
+	if event.transient {
+		level, transient = transientLevel(w, level)
+	}
+	if !level.Enabled(maxLevel) {
+		return nil, nil
+	}
+	return l.write(f, w, event, level, transient, copyMetadata)
+}
+
+// write formats the event with the level and writes it to the writer. It
+// returns the written data, nil if formatting failed, and the pooled buffer
+// holding it which the caller releases once the hooks have run.
+func (l *Logger) write(f formatter.Formatter, w writer.Writer, event *Event, level levels.Level, transient, copyMetadata bool) ([]byte, *[]byte) {
+	metadata := event.metadata
+	if copyMetadata {
+		metadata = make(map[string]interface{}, len(event.metadata))
+		for k, v := range event.metadata {
+			metadata[k] = v
+		}
+	}
+	// formatters run synchronously so the event embeds the formatted event
+	logEvent := &event.formatted
+	*logEvent = formatter.LogEvent{
+		Message:  event.message,
+		Level:    level,
+		Metadata: metadata,
+		Time:     event.time,
+	}
+	var data []byte
+	var buffer *[]byte
+	var err error
+	if appender, ok := f.(formatter.Appender); ok {
+		// writers and hooks must not retain the data, the buffer is reused
+		buffer = acquireBuffer()
+		data, err = appender.AppendFormat(*buffer, logEvent)
+	} else {
+		data, err = f.Format(logEvent)
+	}
+	if err != nil {
+		if buffer != nil {
+			releaseBuffer(buffer, nil)
+		}
+		l.writeErrors.Add(1)
+		return nil, nil
+	}
+	if l.streamPolicy != StreamPolicyNone {
+		l.checkStream(w, level)
 	}
+	if transient {
+		w.(writer.Transient).WriteTransient(data, level)
+	} else {
+		w.Write(data, level)
+	}
+	return data, buffer
 }
 
-// SetMaxLevel sets the max logging level for logger
+// SetMaxLevel sets the max logging level for logger. It can be called
+// while events are logged, e.g. to change the verbosity at runtime.
 func (l *Logger) SetMaxLevel(level levels.Level) {
-	l.maxLevel = level
+	l.maxLevel.Store(int64(level))
+	l.levelSet.Store(true)
 }
 
-// SetFormatter sets the formatter instance for a logger
+// loadMaxLevel returns the max level set on the logger
+func (l *Logger) loadMaxLevel() levels.Level {
+	return levels.Level(l.maxLevel.Load())
+}
+
+// SetFormatter sets the formatter instance for a logger. The formatter is
+// adjusted to the writer capabilities, see negotiateFormatter.
 func (l *Logger) SetFormatter(formatter formatter.Formatter) {
-	l.formatter = formatter
+	l.outputMutex.Lock()
+	defer l.outputMutex.Unlock()
+
+	l.formatter = negotiateFormatter(formatter, l.writer)
 }
 
-// SetWriter sets the writer instance for a logger
+// output returns the formatter and writer of the logger
+func (l *Logger) output() (formatter.Formatter, writer.Writer) {
+	l.outputMutex.RLock()
+	defer l.outputMutex.RUnlock()
+
+	return l.formatter, l.writer
+}
+
+// sinkFormatter returns the formatter of the sink, which can be replaced by
+// SetColorMode while events are logged
+func (l *Logger) sinkFormatter(sink *Sink) formatter.Formatter {
+	l.outputMutex.RLock()
+	defer l.outputMutex.RUnlock()
+
+	return sink.Formatter
+}
+
+// SetColorMode sets the color mode of the logger CLI formatters, the auto
+// mode using colors when the writer of each formatter supports them. The
+// formatters are replaced by copies so that it can be called while events
+// are logged.
+func (l *Logger) SetColorMode(mode formatter.ColorMode) {
+	l.outputMutex.Lock()
+	defer l.outputMutex.Unlock()
+
+	l.formatter = withColorMode(l.formatter, l.writer, mode)
+	for _, sink := range l.sinks {
+		sink.Formatter = withColorMode(sink.Formatter, sink.Writer, mode)
+	}
+}
+
+// withColorMode returns a copy of the CLI formatter with the color mode,
+// adjusted to the writer capabilities in auto mode
+func withColorMode(f formatter.Formatter, w writer.Writer, mode formatter.ColorMode) formatter.Formatter {
+	c, ok := f.(*formatter.CLI)
+	if !ok {
+		return f
+	}
+	c = c.Clone()
+	c.SetColorMode(mode)
+	return negotiateFormatter(c, w)
+}
+
+// SetWriter sets the writer instance for a logger. The logger formatter is
+// adjusted to the writer capabilities, see negotiateFormatter.
 func (l *Logger) SetWriter(writer writer.Writer) {
+	l.outputMutex.Lock()
+	defer l.outputMutex.Unlock()
+
 	l.writer = writer
+	l.formatter = negotiateFormatter(l.formatter, writer)
+}
+
+// SetField sets a metadata item added to every event of the logger
+func (l *Logger) SetField(key string, value interface{}) {
+	if l.fields == nil {
+		l.fields = make(map[string]interface{})
+	}
+	l.fields[key] = value
+}
+
+// SetDefaultFieldCapacity sets the number of metadata items preallocated
+// for each event, avoiding map growth when events typically carry many fields
+func (l *Logger) SetDefaultFieldCapacity(n int) {
+	l.fieldCapacity = n
 }
 
 // SetTimestamp enables/disables automatic timestamp
@@ -82,12 +334,45 @@ func (l *Logger) SetTimestamp(timestamp bool, minLevel levels.Level) {
 	l.timestampMinLevel = minLevel
 }
 
-// Event is a log event to be written with data
+// SetCallerInfo enables/disables automatic caller information
+func (l *Logger) SetCallerInfo(enabled bool, minLevel levels.Level) {
+	l.callerInfo = enabled
+	l.callerMinLevel = minLevel
+}
+
+// EnableStackTraces enables/disables stack trace capture for errors
+// attached with Err on Error and Fatal events
+func (l *Logger) EnableStackTraces(enabled bool) {
+	l.stackTraces = enabled
+}
+
+// Event is a log event to be written with data. Events are recycled
+// once emitted, so they must not be used after Msg, Msgf or MsgFunc.
 type Event struct {
 	logger   *Logger
 	level    levels.Level
 	message  string
-	metadata map[string]string
+	metadata map[string]interface{}
+	time     time.Time
+	// verbosity is the verbosity count of verbose events, see VerbosityN
+	verbosity int
+	// named is the named logger the event was created from, if any
+	named *Logger
+	// forced events bypass sampling, deduplication, rate limiting and the
+	// filter
+	forced bool
+	// transient events are erased by the next event on terminals
+	transient bool
+	// lazy is set when the metadata holds values resolved on emission
+	lazy bool
+	// emitted and origin are used to detect events never emitted in debug mode
+	emitted bool
+	origin  string
+	// pooled events are recycled once emitted
+	pooled bool
+	// capacity is the number of metadata items the map was allocated for
+	capacity  int
+	formatted formatter.LogEvent
 }
 
 func newDefaultEventWithLevel(level levels.Level) *Event {
@@ -95,35 +380,66 @@ func newDefaultEventWithLevel(level levels.Level) *Event {
 }
 
 func newEventWithLevelAndLogger(level levels.Level, l *Logger) *Event {
-	event := &Event{
-		logger:   l,
-		level:    level,
-		metadata: make(map[string]string),
+	if l.parent != nil {
+		event := newEventWithLevelAndLogger(level, l.parent)
+		event.named = l
+		if l.component != "" {
+			event.metadata["component"] = l.component
+		}
+		return event
 	}
-	if l.timestamp && level >= l.timestampMinLevel {
+	event := acquireEvent(l.fieldCapacity)
+	event.logger = l
+	event.level = level
+	event.time = time.Now()
+	if l.timestamp && level.Rank() >= l.timestampMinLevel.Rank() {
 		event.TimeStamp()
 	}
+	for k, v := range l.fields {
+		event.metadata[k] = v
+	}
+	trackEvent(event)
 	return event
 }
 
+// checkNotEmitted reports events mutated after being emitted in debug mode,
+// which corrupts pooled events
+func (e *Event) checkNotEmitted() {
+	if debugMode && e.emitted {
+		reportDebug(fmt.Sprintf("gologger: event mutated after being emitted\n%s", captureStackTrace(4)))
+	}
+}
+
 func (e *Event) setLevelMetadata(level levels.Level) {
 	e.metadata["label"] = labels[level]
 }
 
+// Force makes the event bypass sampling, deduplication, rate limiting and
+// the filter, for events which must always be emitted such as license or safety
+// warnings. The level of the event is still honored.
+func (e *Event) Force() *Event {
+	e.checkNotEmitted()
+	e.forced = true
+	return e
+}
+
 // Label applies a custom label on the log event
 func (e *Event) Label(label string) *Event {
+	e.checkNotEmitted()
 	e.metadata["label"] = label
 	return e
 }
 
 // TimeStamp adds timestamp to the log event
 func (e *Event) TimeStamp() *Event {
-	e.metadata["timestamp"] = time.Now().Format(time.RFC3339)
+	e.checkNotEmitted()
+	e.metadata["timestamp"] = e.time.Format(time.RFC3339)
 	return e
 }
 
 // Str adds a string metadata item to the log
 func (e *Event) Str(key, value string) *Event {
+	e.checkNotEmitted()
 	e.metadata[key] = value
 	return e
 }
@@ -131,12 +447,14 @@ func (e *Event) Str(key, value string) *Event {
 // Msg logs a message to the logger
 func (e *Event) Msg(message string) {
 	e.message = message
+	e.setCaller()
 	e.logger.Log(e)
 }
 
 // Msgf logs a printf style message to the logger
 func (e *Event) Msgf(format string, args ...interface{}) {
 	e.message = fmt.Sprintf(format, args...)
+	e.setCaller()
 	e.logger.Log(e)
 }
 
@@ -144,12 +462,31 @@ func (e *Event) Msgf(format string, args ...interface{}) {
 // Useful when computing the message can be resource heavy.
 func (e *Event) MsgFunc(messageSupplier func() string) {
 	if !isCurrentLevelEnabled(e) {
+		e.emitted = true
+		releaseEvent(e)
 		return
 	}
 	e.message = messageSupplier()
+	e.setCaller()
 	e.logger.Log(e)
 }
 
+// setCaller adds the caller of the Msg* method to the event if enabled.
+// It must be called directly from the Msg* methods to get the right frame.
+func (e *Event) setCaller() {
+	if !e.logger.callerInfo || e.level.Rank() < e.logger.callerMinLevel.Rank() {
+		return
+	}
+	pc, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return
+	}
+	e.metadata["caller"] = filepath.Base(filepath.Dir(file)) + "/" + filepath.Base(file) + ":" + strconv.Itoa(line)
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		e.metadata["function"] = fn.Name()
+	}
+}
+
 // Info writes a info message on the screen with the default label
 func Info() *Event {
 	event := newDefaultEventWithLevel(levels.LevelInfo)
@@ -197,6 +534,11 @@ func Print() *Event {
 	return event
 }
 
+// Audit writes an audit trail event with the default logger
+func Audit() *Event {
+	return DefaultLogger.Audit()
+}
+
 // Verbose prints a string only in verbose output mode.
 func Verbose() *Event {
 	event := newDefaultEventWithLevel(levels.LevelVerbose)
@@ -204,6 +546,43 @@ func Verbose() *Event {
 	return event
 }
 
+// Trace prints a string only in trace output mode, for ultra-verbose
+// output such as protocol dumps.
+func Trace() *Event {
+	event := newDefaultEventWithLevel(levels.LevelTrace)
+	event.setLevelMetadata(levels.LevelTrace)
+	return event
+}
+
+// Audit writes an audit trail event, which is written regardless of the
+// max level and bypasses sampling, deduplication, rate limiting and the
+// filter
+func (l *Logger) Audit() *Event {
+	event := newEventWithLevelAndLogger(levels.LevelAudit, l)
+	event.setLevelMetadata(levels.LevelAudit)
+	event.forced = true
+	return event
+}
+
+// WithLevel returns an event with the level and its default label, for
+// adapters mapping the levels of other logging libraries
+func (l *Logger) WithLevel(level levels.Level) *Event {
+	if level == levels.LevelAudit {
+		return l.Audit()
+	}
+	event := newEventWithLevelAndLogger(level, l)
+	if _, ok := labels[level]; ok {
+		event.setLevelMetadata(level)
+	}
+	return event
+}
+
+// Enabled reports whether the events of the level are written by the
+// logger or one of its sinks
+func (l *Logger) Enabled(level levels.Level) bool {
+	return l.isLevelEnabled(level)
+}
+
 // Info writes a info message on the screen with the default label
 func (l *Logger) Info() *Event {
 	event := newEventWithLevelAndLogger(levels.LevelInfo, l)
@@ -252,6 +631,51 @@ func (l *Logger) Verbose() *Event {
 	return event
 }
 
+// Trace prints a string only in trace output mode, for ultra-verbose
+// output such as protocol dumps.
+func (l *Logger) Trace() *Event {
+	event := newEventWithLevelAndLogger(levels.LevelTrace, l)
+	event.setLevelMetadata(levels.LevelTrace)
+	return event
+}
+
 func isCurrentLevelEnabled(e *Event) bool {
-	return e.level <= e.logger.maxLevel
+	if e.logger.bootstrap.Load() != nil {
+		// the level is not final yet
+		return true
+	}
+	if verbosity := int(e.logger.verbosity.Load()); verbosity > 0 && e.verbosity > verbosity {
+		return false
+	}
+	if e.named != nil {
+		return e.named.isLevelEnabled(e.level)
+	}
+	return e.logger.isLevelEnabled(e.level)
+}
+
+// maxLevel returns the max level of the logger the event was created from
+func (e *Event) maxLevel() levels.Level {
+	if e.named != nil {
+		return e.named.effectiveMaxLevel()
+	}
+	return e.logger.loadMaxLevel()
+}
+
+// isLevelEnabled reports whether the logger or any of its sinks accepts the level
+func (l *Logger) isLevelEnabled(level levels.Level) bool {
+	if level.Enabled(l.effectiveMaxLevel()) {
+		return true
+	}
+	for l.parent != nil {
+		l = l.parent
+	}
+	if l.bootstrap.Load() != nil {
+		return true
+	}
+	for _, sink := range l.loadSinks() {
+		if level.Enabled(sink.MaxLevel) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,96 @@
+package gologger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// discardWriter drops the formatted events
+type discardWriter struct{}
+
+func (discardWriter) Write(data []byte, level levels.Level) {}
+
+func newBenchLogger(f formatter.Formatter) *Logger {
+	l := &Logger{}
+	l.SetFormatter(f)
+	l.SetWriter(discardWriter{})
+	l.SetMaxLevel(levels.LevelDebug)
+	return l
+}
+
+func BenchmarkLoggerLogJSON(b *testing.B) {
+	l := newBenchLogger(&formatter.JSON{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
+
+func BenchmarkLoggerLogCLI(b *testing.B) {
+	l := newBenchLogger(formatter.NewCLI(true))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
+
+func BenchmarkLoggerLogDisabled(b *testing.B) {
+	l := newBenchLogger(&formatter.JSON{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Verbose().Str("host", "example.com").Int("port", 443).Msg("request completed")
+	}
+}
+
+func BenchmarkLoggerLogParallel(b *testing.B) {
+	l := newBenchLogger(&formatter.JSON{})
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info().Str("host", "example.com").Int("port", 443).Msg("request completed")
+		}
+	})
+}
+
+func BenchmarkSlogHandler(b *testing.B) {
+	logger := slog.New(NewSlogHandler(newBenchLogger(&formatter.JSON{}), nil))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.LogAttrs(context.Background(), slog.LevelInfo, "request completed", slog.String("host", "example.com"), slog.Int("port", 443))
+	}
+}
+
+func BenchmarkSlogHandlerWithInner(b *testing.B) {
+	inner := slog.NewJSONHandler(io.Discard, nil)
+	logger := slog.New(NewSlogHandler(newBenchLogger(&formatter.JSON{}), &SlogHandlerOptions{Inner: inner}))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.LogAttrs(context.Background(), slog.LevelInfo, "request completed", slog.String("host", "example.com"), slog.Int("port", 443))
+	}
+}
+
+// benchmarkInterning logs events with values read as bytes, e.g. from
+// responses, repeated across events
+func benchmarkInterning(b *testing.B, max int) {
+	l := newBenchLogger(&formatter.JSON{})
+	l.SetInterning(max)
+	host, templateID := []byte("scanme.sh"), []byte("tech-detect")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info().Bytes("host", host).Bytes("template-id", templateID).Msg("matched")
+	}
+}
+
+func BenchmarkInterningOn(b *testing.B) {
+	benchmarkInterning(b, 1024)
+}
+
+func BenchmarkInterningOff(b *testing.B) {
+	benchmarkInterning(b, 0)
+}
@@ -0,0 +1,46 @@
+package gologger
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// bufferWriter records the formatted events
+type bufferWriter struct {
+	mutex sync.Mutex
+	lines []string
+}
+
+func (b *bufferWriter) Write(data []byte, level levels.Level) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lines = append(b.lines, string(data))
+}
+
+// Lines returns the events written so far
+func (b *bufferWriter) Lines() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return append([]string(nil), b.lines...)
+}
+
+// String returns the events written so far, one per line
+func (b *bufferWriter) String() string {
+	return strings.Join(b.Lines(), "\n")
+}
+
+// newTestLogger returns a logger writing uncolored CLI events up to the
+// debug level to a buffer
+func newTestLogger() (*Logger, *bufferWriter) {
+	w := &bufferWriter{}
+	l := &Logger{}
+	l.SetFormatter(formatter.NewCLI(true))
+	l.SetWriter(w)
+	l.SetMaxLevel(levels.LevelDebug)
+	return l, w
+}
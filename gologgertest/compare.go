@@ -0,0 +1,113 @@
+package gologgertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+	// keyRegex matches the start of a key=value metadata item
+	keyRegex = regexp.MustCompile(`^[A-Za-z0-9_.\-]+=`)
+)
+
+// StripANSI removes the ANSI escape sequences, usually colors, from s
+func StripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
+// ParseMetadata splits a formatted event into its message and metadata.
+// JSON events are decoded, the msg key holding the message, while for the
+// CLI and plain formats the message is the text before the first key=value
+// item, including the bracketed label and timestamp. ANSI escape sequences
+// are removed and the values are returned in their textual form.
+func ParseMetadata(line string) (message string, metadata map[string]string) {
+	line = strings.TrimSpace(StripANSI(line))
+	metadata = make(map[string]string)
+
+	if strings.HasPrefix(line, "{") {
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &fields); err == nil {
+			for k, v := range fields {
+				if k == "msg" {
+					message = fmt.Sprint(v)
+					continue
+				}
+				if s, ok := v.(string); ok {
+					metadata[k] = s
+					continue
+				}
+				data, _ := json.Marshal(v)
+				metadata[k] = string(data)
+			}
+			return message, metadata
+		}
+	}
+
+	var words []string
+	key := ""
+	for _, word := range strings.Split(line, " ") {
+		if keyRegex.MatchString(word) {
+			parts := strings.SplitN(word, "=", 2)
+			key = parts[0]
+			metadata[key] = parts[1]
+			continue
+		}
+		if key != "" {
+			// values containing spaces
+			metadata[key] += " " + word
+			continue
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " "), metadata
+}
+
+// OutputsEqual reports whether two outputs hold the same events regardless
+// of the order of the metadata items and of the ANSI escape sequences.
+// Events are compared line by line.
+func OutputsEqual(a, b string) bool {
+	linesA, linesB := outputLines(a), outputLines(b)
+	if len(linesA) != len(linesB) {
+		return false
+	}
+	for i := range linesA {
+		if !LinesEqual(linesA[i], linesB[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// LinesEqual reports whether two formatted events have the same message
+// and metadata, see ParseMetadata
+func LinesEqual(a, b string) bool {
+	messageA, metadataA := ParseMetadata(a)
+	messageB, metadataB := ParseMetadata(b)
+	return messageA == messageB && reflect.DeepEqual(metadataA, metadataB)
+}
+
+// AssertOutputEqual fails the test if the outputs don't hold the same
+// events, see OutputsEqual
+func AssertOutputEqual(t testing.TB, expected, actual string) {
+	t.Helper()
+
+	if !OutputsEqual(expected, actual) {
+		t.Errorf("outputs differ\nexpected:\n%s\nactual:\n%s", StripANSI(expected), StripANSI(actual))
+	}
+}
+
+// outputLines returns the non empty lines of the output
+func outputLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
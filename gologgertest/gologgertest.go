@@ -0,0 +1,183 @@
+// Package gologgertest provides utilities to test code logging with
+// gologger: a capture writer recording the structured events, assertions
+// on the captured events and a way to swap the default logger in a test.
+package gologgertest
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// Entry is a captured event
+type Entry struct {
+	Level   levels.Level
+	Message string
+	// Fields are the metadata of the event, including its label
+	Fields map[string]interface{}
+	// Data is the formatted event
+	Data []byte
+}
+
+// Capture is a concurrent writer recording the events written by a logger
+// using the formatter returned by Formatter
+type Capture struct {
+	mutex   *sync.Mutex
+	entries []Entry
+}
+
+var _ writer.Writer = &Capture{}
+
+// NewCapture returns a new capture writer
+func NewCapture() *Capture {
+	return &Capture{mutex: &sync.Mutex{}}
+}
+
+// Formatter returns the JSON formatter the captured events are parsed from
+func Formatter() formatter.Formatter {
+	return formatter.NewJSON(formatter.JSONOptions{LabelKey: "label", LevelKey: "level"})
+}
+
+// NewLogger returns a logger at the trace level writing to a new capture
+// writer
+func NewLogger() (*gologger.Logger, *Capture) {
+	capture := NewCapture()
+	logger := &gologger.Logger{}
+	logger.SetWriter(capture)
+	logger.SetFormatter(Formatter())
+	logger.SetMaxLevel(levels.LevelTrace)
+	return logger, capture
+}
+
+// SwapDefault replaces gologger.DefaultLogger with a logger writing to a
+// new capture writer for the duration of the test, the previous logger
+// being restored on cleanup. Tests using it must not run in parallel.
+func SwapDefault(t testing.TB) *Capture {
+	t.Helper()
+
+	logger, capture := NewLogger()
+	previous := gologger.DefaultLogger
+	gologger.DefaultLogger = logger
+	t.Cleanup(func() {
+		gologger.DefaultLogger = previous
+	})
+	return capture
+}
+
+// Write records the event
+func (c *Capture) Write(data []byte, level levels.Level) {
+	entry := Entry{Level: level, Data: append([]byte(nil), data...), Message: string(data)}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err == nil {
+		if msg, ok := fields["msg"].(string); ok {
+			entry.Message = msg
+		}
+		delete(fields, "msg")
+		delete(fields, "level")
+		entry.Fields = fields
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = append(c.entries, entry)
+}
+
+// Entries returns the captured events in the order they were written
+func (c *Capture) Entries() []Entry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]Entry(nil), c.entries...)
+}
+
+// Len returns the number of captured events
+func (c *Capture) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// Count returns the number of captured events with the level
+func (c *Capture) Count(level levels.Level) int {
+	return len(c.ByLevel(level))
+}
+
+// ByLevel returns the captured events with the level
+func (c *Capture) ByLevel(level levels.Level) []Entry {
+	var entries []Entry
+	for _, entry := range c.Entries() {
+		if entry.Level == level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Reset removes the captured events
+func (c *Capture) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = nil
+}
+
+// Logged reports whether an event with the level and a message containing
+// substring was captured
+func (c *Capture) Logged(level levels.Level, substring string) bool {
+	for _, entry := range c.ByLevel(level) {
+		if strings.Contains(entry.Message, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertLogged fails the test if no event with the level and a message
+// containing substring was captured
+func (c *Capture) AssertLogged(t testing.TB, level levels.Level, substring string) {
+	t.Helper()
+
+	if !c.Logged(level, substring) {
+		t.Errorf("no %s event containing %q was logged, got:\n%s", level, substring, c)
+	}
+}
+
+// AssertNotLogged fails the test if an event with the level and a message
+// containing substring was captured
+func (c *Capture) AssertNotLogged(t testing.TB, level levels.Level, substring string) {
+	t.Helper()
+
+	if c.Logged(level, substring) {
+		t.Errorf("unexpected %s event containing %q was logged", level, substring)
+	}
+}
+
+// AssertCount fails the test if the number of captured events with the
+// level is not count
+func (c *Capture) AssertCount(t testing.TB, level levels.Level, count int) {
+	t.Helper()
+
+	if got := c.Count(level); got != count {
+		t.Errorf("expected %d %s events, got %d", count, level, got)
+	}
+}
+
+// String returns the captured events, one per line
+func (c *Capture) String() string {
+	var builder strings.Builder
+	for _, entry := range c.Entries() {
+		builder.WriteString(entry.Level.String())
+		builder.WriteString(": ")
+		builder.Write(entry.Data)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,121 @@
+package gologger
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Heartbeat periodically emits an info event labeled HBT with the uptime,
+// the number of events logged, the event rate since the previous heartbeat,
+// the number of errors and the gauges registered by the application,
+// replacing the hand-rolled status ticker loops of long running tools.
+type Heartbeat struct {
+	logger *Logger
+	start  time.Time
+	ticker *time.Ticker
+	done   chan struct{}
+	once   sync.Once
+
+	mutex  sync.Mutex
+	gauges map[string]func() interface{}
+	// last is the time and event count of the previous heartbeat
+	lastTime  time.Time
+	lastTotal uint64
+}
+
+// StartHeartbeat starts emitting a heartbeat event every interval, until
+// Stop is called
+func (l *Logger) StartHeartbeat(interval time.Duration) *Heartbeat {
+	now := time.Now()
+	h := &Heartbeat{
+		logger:    l,
+		start:     now,
+		ticker:    time.NewTicker(interval),
+		done:      make(chan struct{}),
+		gauges:    make(map[string]func() interface{}),
+		lastTime:  now,
+		lastTotal: totalEvents(l.counters.snapshot()),
+	}
+	go h.run()
+	return h
+}
+
+// StartHeartbeat starts emitting a heartbeat event on the default logger
+// every interval
+func StartHeartbeat(interval time.Duration) *Heartbeat {
+	return DefaultLogger.StartHeartbeat(interval)
+}
+
+// Gauge registers a value added to each heartbeat event under name, such as
+// the number of targets left. value is called at each heartbeat.
+func (h *Heartbeat) Gauge(name string, value func() interface{}) *Heartbeat {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.gauges[name] = value
+	return h
+}
+
+// Stop stops emitting heartbeat events
+func (h *Heartbeat) Stop() {
+	h.once.Do(func() {
+		h.ticker.Stop()
+		close(h.done)
+	})
+}
+
+func (h *Heartbeat) run() {
+	for {
+		select {
+		case <-h.ticker.C:
+			h.beat()
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// beat emits a heartbeat event
+func (h *Heartbeat) beat() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := time.Now()
+	counts := h.logger.counters.snapshot()
+	total := totalEvents(counts)
+	rate := 0.0
+	if elapsed := now.Sub(h.lastTime).Seconds(); elapsed > 0 && total > h.lastTotal {
+		rate = float64(total-h.lastTotal) / elapsed
+	}
+
+	event := h.logger.Info().
+		Label("HBT").
+		Dur("uptime", now.Sub(h.start).Round(time.Second)).
+		Uint64("events", total).
+		Float64("events_per_sec", math.Round(rate*100)/100).
+		Uint64("errors", counts["error"]+counts["fatal"])
+	names := make([]string, 0, len(h.gauges))
+	for name := range h.gauges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		event.Any(name, h.gauges[name]())
+	}
+	event.Msg("heartbeat")
+
+	// the heartbeat itself is not counted in the rate of the next one
+	h.lastTime = now
+	h.lastTotal = totalEvents(h.logger.counters.snapshot())
+}
+
+// totalEvents returns the sum of the per level counts
+func totalEvents(counts map[string]uint64) uint64 {
+	var total uint64
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
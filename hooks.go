@@ -0,0 +1,108 @@
+package gologger
+
+import (
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Hook is invoked around the formatting and writing of the logger events.
+// Events and formatted data are recycled once logged, so hooks must not
+// retain them: the values needed later, such as the message or a copy of
+// the data, must be copied before the hook returns.
+type Hook interface {
+	// BeforeFormat is called before the event is formatted and can mutate it.
+	// Returning false drops the event, except for fatal events which are
+	// always written before the logger exits.
+	BeforeFormat(event *Event) bool
+	// AfterWrite is called once per event after it has been written to the
+	// output and the sinks, with the data of the first destination written,
+	// the output unless its level was disabled. The event and the data are
+	// recycled once the hooks return and must not be retained.
+	AfterWrite(event *Event, data []byte)
+}
+
+// AddHook registers a hook on the logger. Hooks are run in registration order.
+func (l *Logger) AddHook(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// runBeforeFormat runs the hooks returning false if the event was vetoed.
+// The veto is ignored for fatal events so that the exit policy still applies.
+func (l *Logger) runBeforeFormat(event *Event) bool {
+	for _, hook := range l.hooks {
+		if !hook.BeforeFormat(event) && event.level != levels.LevelFatal {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *Logger) runAfterWrite(event *Event, data []byte) {
+	for _, hook := range l.hooks {
+		hook.AfterWrite(event, data)
+	}
+}
+
+// Level returns the level of the event
+func (e *Event) Level() levels.Level {
+	return e.level
+}
+
+// SetLevel changes the level of the event along with its default label
+func (e *Event) SetLevel(level levels.Level) *Event {
+	if label, ok := e.metadata["label"]; ok && label == labels[e.level] {
+		delete(e.metadata, "label")
+		if _, ok := labels[level]; ok {
+			e.setLevelMetadata(level)
+		}
+	}
+	e.level = level
+	return e
+}
+
+// Message returns the message of the event
+func (e *Event) Message() string {
+	return e.message
+}
+
+// SetMessage replaces the message of the event
+func (e *Event) SetMessage(message string) *Event {
+	e.message = message
+	return e
+}
+
+// Field returns the metadata item with the given key
+func (e *Event) Field(key string) (interface{}, bool) {
+	value, ok := e.metadata[key]
+	return value, ok
+}
+
+// MetadataLen returns the number of metadata items of the event
+func (e *Event) MetadataLen() int {
+	return len(e.metadata)
+}
+
+// DeleteField removes the metadata item with the given key
+func (e *Event) DeleteField(key string) *Event {
+	delete(e.metadata, key)
+	return e
+}
+
+// emitHook mirrors the written lines to a callback
+type emitHook struct {
+	callback func(level, line string)
+}
+
+func (h *emitHook) BeforeFormat(event *Event) bool {
+	return true
+}
+
+func (h *emitHook) AfterWrite(event *Event, data []byte) {
+	h.callback(event.level.String(), string(data))
+}
+
+// OnEmit registers a callback invoked once with the level and formatted line
+// each time an event has been written, allowing to mirror the output in
+// a user interface without replacing the writers.
+func (l *Logger) OnEmit(callback func(level, line string)) {
+	l.AddHook(&emitHook{callback: callback})
+}
@@ -0,0 +1,76 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestAfterWriteOncePerEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		sinkLevel levels.Level
+		level     levels.Level
+		calls     int
+	}{
+		{"output and sinks", levels.LevelDebug, levels.LevelInfo, 1},
+		{"output only", levels.LevelError, levels.LevelInfo, 1},
+		{"sinks only", levels.LevelTrace, levels.LevelTrace, 1},
+		{"disabled", levels.LevelError, levels.LevelTrace, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, _ := newTestLogger()
+			l.AddSink(NewSink(formatter.NewCLI(true), &bufferWriter{}, test.sinkLevel))
+			l.AddSink(NewSink(formatter.NewCLI(true), &bufferWriter{}, test.sinkLevel))
+
+			var lines []string
+			l.OnEmit(func(level, line string) {
+				if level != test.level.String() {
+					t.Errorf("level = %s, want %s", level, test.level)
+				}
+				lines = append(lines, line)
+			})
+			l.WithLevel(test.level).Msg("event")
+
+			if len(lines) != test.calls {
+				t.Fatalf("got %d calls %q, want %d", len(lines), lines, test.calls)
+			}
+			for _, line := range lines {
+				if !strings.Contains(line, "event") {
+					t.Errorf("line = %q, want the event", line)
+				}
+			}
+		})
+	}
+}
+
+// vetoHook drops every event before it is formatted
+type vetoHook struct{}
+
+func (vetoHook) BeforeFormat(event *Event) bool { return false }
+
+func (vetoHook) AfterWrite(event *Event, data []byte) {}
+
+func TestBeforeFormatFatalVeto(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetFatalPolicy(FatalPanic)
+	l.AddHook(vetoHook{})
+
+	l.Error().Msg("dropped")
+	func() {
+		defer func() {
+			if err, ok := recover().(*FatalError); !ok || err.Code != 1 {
+				t.Errorf("recovered %v, want a fatal error", err)
+			}
+		}()
+		l.Fatal().Msg("fatal")
+		t.Error("Fatal returned after the veto")
+	}()
+
+	if got := w.String(); got != "[FTL] fatal exit_code=1" {
+		t.Errorf("output = %q, want the fatal event only", got)
+	}
+}
@@ -0,0 +1,57 @@
+package gologger
+
+import "sync"
+
+// interner is a bounded cache deduplicating repeated strings
+type interner struct {
+	mutex   sync.RWMutex
+	strings map[string]string
+	max     int
+}
+
+func newInterner(max int) *interner {
+	return &interner{strings: make(map[string]string), max: max}
+}
+
+// intern returns the cached copy of s, caching it if there is room left
+func (i *interner) intern(s string) string {
+	i.mutex.RLock()
+	cached, ok := i.strings[s]
+	i.mutex.RUnlock()
+	if ok {
+		return cached
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if len(i.strings) < i.max {
+		i.strings[s] = s
+	}
+	return s
+}
+
+// internBytes returns the cached string for b without allocating on hits
+func (i *interner) internBytes(b []byte) string {
+	i.mutex.RLock()
+	// the compiler doesn't allocate for string(b) used as map key
+	cached, ok := i.strings[string(b)]
+	i.mutex.RUnlock()
+	if ok {
+		return cached
+	}
+	return i.intern(string(b))
+}
+
+// SetInterning enables interning of the metadata values converted from
+// bytes, those of Event.Bytes and the callers parsed from the standard log
+// lines by RedirectStdLog, up to max distinct strings. Repeated values then don't allocate.
+// Values and keys passed as strings are already allocated by the caller,
+// usually as constants, and are left as is. A max of 0 disables it.
+func (l *Logger) SetInterning(max int) {
+	if max <= 0 {
+		l.interner = nil
+		return
+	}
+	l.interner = newInterner(max)
+}
@@ -0,0 +1,74 @@
+package gologger
+
+import (
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestInternerBounded(t *testing.T) {
+	i := newInterner(2)
+	for _, value := range []string{"a", "b", "a", "c", "d"} {
+		if got := i.intern(value); got != value {
+			t.Errorf("intern(%q) = %q", value, got)
+		}
+	}
+	if len(i.strings) != 2 {
+		t.Errorf("cached %d strings, want 2", len(i.strings))
+	}
+	if _, ok := i.strings["c"]; ok {
+		t.Error("string cached beyond the bound")
+	}
+}
+
+func TestInternBytes(t *testing.T) {
+	i := newInterner(1)
+	first := i.internBytes([]byte("value"))
+	second := i.internBytes([]byte("value"))
+	if first != "value" || unsafe.StringData(first) != unsafe.StringData(second) {
+		t.Error("interned strings don't share their data")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { i.internBytes([]byte("value")) }); allocs != 0 {
+		t.Errorf("internBytes allocated %v times on a hit", allocs)
+	}
+}
+
+func TestSetInterning(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetInterning(2)
+	l.Info().Bytes("host", []byte("example.com")).Msg("first")
+	l.Info().Bytes("host", []byte("example.com")).Msg("second")
+	l.Info().Str("template-id", "tech-detect").Msg("strings are not interned")
+	if len(l.interner.strings) != 1 {
+		t.Errorf("expected the repeated value to be interned once, got %v", l.interner.strings)
+	}
+	if lines := w.Lines(); len(lines) != 3 || lines[1] != "[INF] second host=example.com" {
+		t.Errorf("unexpected output %q", lines)
+	}
+
+	l.SetInterning(0)
+	if l.interner != nil {
+		t.Error("interning not disabled")
+	}
+}
+
+func TestInterningStdLogCaller(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetInterning(8)
+	std := log.New(io.Discard, "", log.Lshortfile)
+	std.SetOutput(&lineWriter{logger: l, level: levels.LevelInfo, stdLogger: std})
+
+	for i := 0; i < 2; i++ {
+		std.Print("repeated")
+	}
+	if len(l.interner.strings) != 1 {
+		t.Errorf("expected the callers to be interned, got %v", l.interner.strings)
+	}
+	if lines := w.Lines(); len(lines) != 2 || lines[0] != lines[1] || !strings.HasPrefix(lines[0], "[INF] [intern_test.go:") {
+		t.Errorf("unexpected output %q", lines)
+	}
+}
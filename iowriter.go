@@ -0,0 +1,148 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// lineWriter is an io.WriteCloser emitting each written line as an event
+type lineWriter struct {
+	mutex  sync.Mutex
+	logger *Logger
+	level  levels.Level
+	buffer []byte
+	// slogJSON parses the lines as records of a slog JSON handler
+	slogJSON bool
+	// stdLogger parses the prefix, time and file written by the logger
+	stdLogger *log.Logger
+	// fields are added to the events
+	fields map[string]interface{}
+}
+
+// Writer returns an io.WriteCloser splitting the written data on newlines
+// and logging each line as an event at the given level. Close flushes the
+// last line if it isn't newline terminated.
+func (l *Logger) Writer(level levels.Level) io.WriteCloser {
+	return &lineWriter{logger: l, level: level}
+}
+
+// SlogJSONWriter returns an io.WriteCloser accepting the output of a slog
+// JSON handler, each record being parsed and logged as an event with its
+// level, time, message and attributes, so that the records get the same
+// look as the native events. Lines which aren't JSON records are logged
+// at the info level.
+func (l *Logger) SlogJSONWriter() io.WriteCloser {
+	return &lineWriter{logger: l, level: levels.LevelInfo, slogJSON: true}
+}
+
+// StdLogger returns a *log.Logger writing its output to the logger at the
+// given level, e.g. for http.Server.ErrorLog
+func (l *Logger) StdLogger(level levels.Level) *log.Logger {
+	return log.New(l.Writer(level), "", 0)
+}
+
+// Write logs each complete line of p
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.buffer = append(w.buffer, p...)
+	for {
+		i := bytes.IndexByte(w.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.buffer[:i])
+		w.buffer = w.buffer[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close logs the remaining partial line
+func (w *lineWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.buffer) > 0 {
+		w.emit(w.buffer)
+		w.buffer = nil
+	}
+	return nil
+}
+
+func (w *lineWriter) emit(line []byte) {
+	line = bytes.TrimSuffix(line, []byte("\r"))
+	if len(line) == 0 {
+		return
+	}
+	if w.slogJSON && line[0] == '{' {
+		if event, ok := w.parseSlogJSON(line); ok {
+			w.logger.Log(event)
+			return
+		}
+	}
+	event := newEventWithLevelAndLogger(w.level, w.logger)
+	if _, ok := labels[w.level]; ok {
+		event.setLevelMetadata(w.level)
+	}
+	for k, v := range w.fields {
+		event.metadata[k] = v
+	}
+	if w.stdLogger != nil {
+		line = parseStdLogHeader(event, w.stdLogger, line)
+	}
+	event.message = string(line)
+	w.logger.Log(event)
+}
+
+// parseSlogJSON returns the event of a slog JSON handler record
+func (w *lineWriter) parseSlogJSON(line []byte) (*Event, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var record map[string]interface{}
+	if err := decoder.Decode(&record); err != nil {
+		return nil, false
+	}
+	level := w.level
+	if name, ok := record[slog.LevelKey].(string); ok {
+		var slogLevel slog.Level
+		if err := slogLevel.UnmarshalText([]byte(name)); err == nil {
+			level = fromSlogLevel(slogLevel)
+		}
+	}
+	event := newEventWithLevelAndLogger(level, w.logger)
+	event.setLevelMetadata(level)
+	if value, ok := record[slog.TimeKey].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			event.time = t
+			if _, ok := event.metadata["timestamp"]; ok {
+				event.TimeStamp()
+			}
+		}
+	}
+	if source, ok := record[slog.SourceKey].(map[string]interface{}); ok {
+		file, _ := source["file"].(string)
+		event.metadata["caller"] = filepath.Base(filepath.Dir(file)) + "/" + filepath.Base(file) + ":" + fmt.Sprint(source["line"])
+		if function, ok := source["function"].(string); ok {
+			event.metadata["function"] = function
+		}
+	}
+	event.message, _ = record[slog.MessageKey].(string)
+	for k, v := range record {
+		switch k {
+		case slog.TimeKey, slog.LevelKey, slog.MessageKey, slog.SourceKey:
+			continue
+		}
+		event.metadata[k] = v
+	}
+	return event, true
+}
@@ -0,0 +1,52 @@
+package gologger
+
+import (
+	"context"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+type labelContextKey struct{}
+
+// PushLabel returns a logger replacing the default label of the events
+// (e.g. [INF]) with label, so that nested operations such as retries are
+// visible. Error and Fatal events keep their labels. The returned logger
+// shares the level and outputs of l and PopLabel returns l, so the label is
+// scoped to the code using the returned logger, unlike a label stack shared
+// by the goroutines. ContextWithLabel scopes a label to a context instead.
+func (l *Logger) PushLabel(label string) *Logger {
+	return &Logger{parent: l, component: l.component, pushedLabel: label, labelPushed: true}
+}
+
+// PopLabel returns the logger PushLabel was called on, or l if it was not
+// returned by PushLabel
+func (l *Logger) PopLabel() *Logger {
+	if l.labelPushed {
+		return l.parent
+	}
+	return l
+}
+
+// ContextWithLabel returns a copy of ctx carrying a label which replaces
+// the default label of the events passed ctx with Event.Ctx
+func ContextWithLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, labelContextKey{}, label)
+}
+
+// overrideLabel replaces the default label of the event
+func (e *Event) overrideLabel(label string) {
+	if label == "" || e.level == levels.LevelError || e.level == levels.LevelFatal {
+		return
+	}
+	if current, ok := e.metadata["label"].(string); ok && current == labels[e.level] {
+		e.metadata["label"] = label
+	}
+}
+
+// applyPushedLabel replaces the default label of the event with the label
+// pushed on the logger the event was created from
+func (l *Logger) applyPushedLabel(event *Event) {
+	if event.named != nil {
+		event.overrideLabel(event.named.pushedLabel)
+	}
+}
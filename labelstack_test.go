@@ -0,0 +1,63 @@
+package gologger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPushLabel(t *testing.T) {
+	l, w := newTestLogger()
+
+	retry := l.PushLabel("RETRY")
+	retry.Info().Msg("retrying")
+	retry.Error().Msg("failed")
+	nested := retry.PushLabel("BACKOFF")
+	nested.Info().Msg("waiting")
+	nested.PopLabel().Info().Msg("retrying again")
+	l.Info().Msg("done")
+
+	expected := []string{"[RETRY] retrying", "[ERR] failed", "[BACKOFF] waiting", "[RETRY] retrying again", "[INF] done"}
+	if lines := w.Lines(); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got %q, want %q", lines, expected)
+	}
+	if retry.PopLabel() != l || l.PopLabel() != l {
+		t.Error("PopLabel did not return the parent logger")
+	}
+}
+
+func TestPushLabelConcurrent(t *testing.T) {
+	l, w := newTestLogger()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			label := fmt.Sprintf("W%d", i)
+			scoped := l.PushLabel(label)
+			for j := 0; j < 100; j++ {
+				scoped.Info().Msg(label)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, line := range w.Lines() {
+		label, message, _ := strings.Cut(line, " ")
+		if label != "["+message+"]" {
+			t.Fatalf("event got the label of another goroutine: %q", line)
+		}
+	}
+}
+
+func TestPushLabelNamed(t *testing.T) {
+	l, w := newTestLogger()
+	named := &Logger{parent: l, component: "dns"}
+
+	named.PushLabel("RETRY").Info().Msg("resolving")
+	if output := w.String(); output != "[RETRY] resolving component=dns" {
+		t.Errorf("unexpected output %q", output)
+	}
+}
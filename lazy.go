@@ -0,0 +1,90 @@
+package gologger
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// lazyValue is a metadata value resolved when the event is emitted
+type lazyValue struct {
+	value interface{}
+}
+
+// Lazy adds a metadata item whose value is resolved only when the event is
+// emitted, so that expensive fmt.Stringer or slog.LogValuer implementations
+// (e.g. parsed certificates) cost nothing for disabled or sampled events.
+// Other values are added as-is.
+func (e *Event) Lazy(key string, value interface{}) *Event {
+	e.checkNotEmitted()
+	switch value.(type) {
+	case slog.LogValuer, fmt.Stringer:
+		e.metadata[key] = lazyValue{value: value}
+		e.lazy = true
+	default:
+		e.metadata[key] = value
+	}
+	return e
+}
+
+// LogObjectMarshaler is implemented by types defining how they are logged,
+// such as scan results or HTTP responses, by adding their fields to the
+// event with the usual methods (e.g. e.Str("host", r.Host))
+type LogObjectMarshaler interface {
+	MarshalLogObject(e *Event)
+}
+
+// Object adds a nested object built by the marshaler, rendered like Dict.
+// The marshaler is called only when the event is emitted.
+func (e *Event) Object(key string, marshaler LogObjectMarshaler) *Event {
+	e.checkNotEmitted()
+	if marshaler == nil {
+		return e
+	}
+	e.metadata[key] = lazyValue{value: marshaler}
+	e.lazy = true
+	return e
+}
+
+// resolveLazy replaces the lazy metadata values with their resolved value
+func (e *Event) resolveLazy() {
+	if !e.lazy {
+		return
+	}
+	for k, v := range e.metadata {
+		if lazy, ok := v.(lazyValue); ok {
+			e.metadata[k] = e.resolveLazyValue(lazy.value)
+		}
+	}
+	e.lazy = false
+}
+
+// resolveLazyValue resolves the value, objects being built on a sub-event
+// with the level of the event so that level dependent fields such as stack
+// traces behave as on the event itself
+func (e *Event) resolveLazyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case LogObjectMarshaler:
+		object := &Event{logger: e.logger, level: e.level, metadata: make(map[string]interface{})}
+		v.MarshalLogObject(object)
+		object.resolveLazy()
+		return object.metadata
+	case slog.LogValuer:
+		return slogValue(slog.AnyValue(v).Resolve())
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// slogValue converts a resolved slog value to a metadata value
+func slogValue(value slog.Value) interface{} {
+	if value.Kind() != slog.KindGroup {
+		return value.Any()
+	}
+	group := make(map[string]interface{})
+	for _, attr := range value.Group() {
+		group[attr.Key] = slogValue(attr.Value.Resolve())
+	}
+	return group
+}
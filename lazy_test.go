@@ -0,0 +1,42 @@
+package gologger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// failedRequest logs its error when marshaled
+type failedRequest struct{}
+
+func (failedRequest) MarshalLogObject(e *Event) {
+	e.Str("host", "example.com").Err(errors.New("timeout"))
+}
+
+func TestObjectLevel(t *testing.T) {
+	tests := []struct {
+		level      levels.Level
+		stackTrace bool
+	}{
+		{levels.LevelInfo, false},
+		{levels.LevelWarning, false},
+		{levels.LevelError, true},
+	}
+	for _, test := range tests {
+		t.Run(test.level.String(), func(t *testing.T) {
+			l, w := newTestLogger()
+			l.EnableStackTraces(true)
+			l.WithLevel(test.level).Object("request", failedRequest{}).Msg("request")
+
+			output := w.String()
+			if !strings.Contains(output, "request.error=timeout") {
+				t.Errorf("output = %q, want the object error", output)
+			}
+			if got := strings.Contains(output, "request.stacktrace="); got != test.stackTrace {
+				t.Errorf("stack trace captured = %v, want %v in %q", got, test.stackTrace, output)
+			}
+		})
+	}
+}
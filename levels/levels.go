@@ -1,20 +1,132 @@
 package levels
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // Level defines all the available levels we can log at
 type Level int
 
-// Available logging levels
+// Available logging levels. The numeric values are stable and can be
+// relied upon by external tooling and configuration files.
 const (
-	LevelFatal Level = iota
-	LevelSilent
-	LevelError
-	LevelInfo
-	LevelWarning
-	LevelDebug
-	LevelVerbose
+	LevelFatal   Level = 0
+	LevelSilent  Level = 1
+	LevelError   Level = 2
+	LevelInfo    Level = 3
+	LevelWarning Level = 4
+	LevelDebug   Level = 5
+	LevelVerbose Level = 6
+	LevelTrace   Level = 7
+	// LevelAudit is for audit trail events, which are more severe than info
+	// ones and always written regardless of the max level
+	LevelAudit Level = 8
 )
 
+var names = [...]string{"fatal", "silent", "error", "info", "warning", "debug", "verbose", "trace", "audit"}
+
+// aliases are the alternative names accepted by Parse
+var aliases = map[string]Level{
+	"warn": LevelWarning,
+	"err":  LevelError,
+}
+
 // String returns the string representation of a log level
 func (l Level) String() string {
-	return [...]string{"fatal", "silent", "error", "info", "warning", "debug", "verbose"}[l]
+	if l < 0 || int(l) >= len(names) {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return names[l]
+}
+
+// All returns all the available levels in increasing verbosity order, the
+// audit level, which is always enabled, coming first after fatal
+func All() []Level {
+	return []Level{LevelFatal, LevelAudit, LevelSilent, LevelError, LevelInfo, LevelWarning, LevelDebug, LevelVerbose, LevelTrace}
+}
+
+// Enabled reports whether the level is enabled with the max level, audit
+// events being always enabled
+func (l Level) Enabled(max Level) bool {
+	return l.Rank() <= max.Rank() || l == LevelAudit
+}
+
+// Rank returns the position of the level in increasing verbosity order,
+// see All. Levels are compared by rank since the audit level is numerically
+// the highest while being one of the least verbose.
+func (l Level) Rank() int {
+	switch {
+	case l == LevelAudit:
+		return 1
+	case l > LevelFatal && l < LevelAudit:
+		return int(l) + 1
+	default:
+		return int(l)
+	}
+}
+
+// IsDiagnostic reports whether the level is one of the debug, verbose and
+// trace levels, which can be dropped without losing operational events
+func (l Level) IsDiagnostic() bool {
+	return l == LevelDebug || l == LevelVerbose || l == LevelTrace
+}
+
+// Parse returns the level with the given name, case-insensitively. The
+// aliases "warn" and "err" are accepted as well.
+func Parse(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, n := range names {
+		if n == name {
+			return Level(i), nil
+		}
+	}
+	if level, ok := aliases[name]; ok {
+		return level, nil
+	}
+	return 0, fmt.Errorf("invalid level %q", name)
+}
+
+// MarshalText encodes the level as its name
+func (l Level) MarshalText() ([]byte, error) {
+	if l < 0 || int(l) >= len(names) {
+		return nil, fmt.Errorf("invalid level %d", int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText decodes the level from its name, see Parse
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// MarshalJSON encodes the level as its name
+func (l Level) MarshalJSON() ([]byte, error) {
+	if l < 0 || int(l) >= len(names) {
+		return nil, fmt.Errorf("invalid level %d", int(l))
+	}
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON decodes the level from its name or numeric value
+func (l *Level) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		if number < 0 || number >= len(names) {
+			return fmt.Errorf("invalid level %d", number)
+		}
+		*l = Level(number)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(name))
 }
@@ -0,0 +1,42 @@
+package levels
+
+import "testing"
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		level, max Level
+		enabled    bool
+	}{
+		{LevelError, LevelInfo, true},
+		{LevelInfo, LevelInfo, true},
+		{LevelDebug, LevelInfo, false},
+		{LevelAudit, LevelFatal, true},
+		{LevelAudit, LevelSilent, true},
+		{LevelFatal, LevelAudit, true},
+		{LevelError, LevelAudit, false},
+		{LevelTrace, LevelAudit, false},
+		{LevelTrace, LevelTrace, true},
+	}
+	for _, test := range tests {
+		if got := test.level.Enabled(test.max); got != test.enabled {
+			t.Errorf("%s.Enabled(%s) = %v, want %v", test.level, test.max, got, test.enabled)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	for i, level := range All() {
+		if level.Rank() != i {
+			t.Errorf("%s.Rank() = %d, want %d", level, level.Rank(), i)
+		}
+	}
+}
+
+func TestIsDiagnostic(t *testing.T) {
+	diagnostic := map[Level]bool{LevelDebug: true, LevelVerbose: true, LevelTrace: true}
+	for _, level := range All() {
+		if got := level.IsDiagnostic(); got != diagnostic[level] {
+			t.Errorf("%s.IsDiagnostic() = %v, want %v", level, got, diagnostic[level])
+		}
+	}
+}
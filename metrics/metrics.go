@@ -0,0 +1,92 @@
+// Package metrics exposes the activity of a gologger logger as prometheus
+// collectors which can be registered by the host application.
+package metrics
+
+import (
+	"github.com/projectdiscovery/gologger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus collector fed by a hook registered on a logger.
+//
+// It exposes the following metrics:
+//   - log_lines_total{level}: lines written per level
+//   - log_bytes_total: bytes written
+//   - dropped_events_total: events dropped by the rate limit and the writers
+//   - write_errors_total: events which could not be formatted
+type Collector struct {
+	logger      *gologger.Logger
+	lines       *prometheus.CounterVec
+	bytes       prometheus.Counter
+	dropped     *prometheus.Desc
+	writeErrors *prometheus.Desc
+}
+
+var (
+	_ prometheus.Collector = &Collector{}
+	_ gologger.Hook        = &Collector{}
+)
+
+// New returns a new collector for the logger, registering its hook on it.
+// The collector must then be registered with a prometheus registry.
+func New(logger *gologger.Logger) *Collector {
+	return NewWithNamespace(logger, "")
+}
+
+// NewWithNamespace returns a new collector for the logger with the metric
+// names prefixed by the namespace
+func NewWithNamespace(logger *gologger.Logger, namespace string) *Collector {
+	c := &Collector{
+		logger: logger,
+		lines: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "log_lines_total",
+			Help:      "Number of log lines written per level.",
+		}, []string{"level"}),
+		bytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "log_bytes_total",
+			Help:      "Number of log bytes written.",
+		}),
+		dropped: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "dropped_events_total"),
+			"Number of log events dropped by the rate limit and the writers.",
+			nil, nil,
+		),
+		writeErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "write_errors_total"),
+			"Number of log events which could not be formatted.",
+			nil, nil,
+		),
+	}
+	logger.AddHook(c)
+	return c
+}
+
+// BeforeFormat lets every event through
+func (c *Collector) BeforeFormat(event *gologger.Event) bool {
+	return true
+}
+
+// AfterWrite counts the written line and its bytes
+func (c *Collector) AfterWrite(event *gologger.Event, data []byte) {
+	c.lines.WithLabelValues(event.Level().String()).Inc()
+	c.bytes.Add(float64(len(data)))
+}
+
+// Describe sends the descriptors of the metrics
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.lines.Describe(ch)
+	c.bytes.Describe(ch)
+	ch <- c.dropped
+	ch <- c.writeErrors
+}
+
+// Collect sends the current values of the metrics
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.lines.Collect(ch)
+	c.bytes.Collect(ch)
+	stats := c.logger.Stats()
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(stats.Dropped))
+	ch <- prometheus.MustNewConstMetric(c.writeErrors, prometheus.CounterValue, float64(stats.WriteErrors))
+}
@@ -0,0 +1,57 @@
+package gologger
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+var (
+	namedMutex   sync.Mutex
+	namedLoggers = make(map[string]*Logger)
+)
+
+// Named returns the logger of the named component (e.g. "dns"), creating
+// it on first use. Events of a named logger are tagged with the component
+// name and written by DefaultLogger, but their level can be set
+// independently with SetLevelFor. Until then the DefaultLogger level is used.
+func Named(name string) *Logger {
+	namedMutex.Lock()
+	defer namedMutex.Unlock()
+
+	if l, ok := namedLoggers[name]; ok {
+		return l
+	}
+	l := &Logger{parent: DefaultLogger, component: name}
+	namedLoggers[name] = l
+	return l
+}
+
+// SetLevelFor sets the max level of the named component logger, allowing
+// to raise the verbosity of one subsystem at runtime
+func SetLevelFor(name string, level levels.Level) {
+	Named(name).SetMaxLevel(level)
+}
+
+// Components returns the names of the registered component loggers
+func Components() []string {
+	namedMutex.Lock()
+	defer namedMutex.Unlock()
+
+	names := make([]string, 0, len(namedLoggers))
+	for name := range namedLoggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// effectiveMaxLevel returns the max level of the logger, which is inherited
+// from the parent for named loggers without an explicit level
+func (l *Logger) effectiveMaxLevel() levels.Level {
+	if l.parent != nil && !l.levelSet.Load() {
+		return l.parent.effectiveMaxLevel()
+	}
+	return l.loadMaxLevel()
+}
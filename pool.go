@@ -0,0 +1,71 @@
+package gologger
+
+import "sync"
+
+// maxPooledBufferSize is the capacity above which formatting buffers are
+// not recycled, so that a few huge events don't pin memory
+const maxPooledBufferSize = 64 * 1024
+
+var (
+	eventPool = sync.Pool{
+		New: func() interface{} {
+			return &Event{metadata: make(map[string]interface{})}
+		},
+	}
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			buffer := make([]byte, 0, 512)
+			return &buffer
+		},
+	}
+)
+
+// acquireEvent returns a recycled event whose metadata map is allocated
+// for at least capacity items. Events aren't recycled in debug builds so
+// that events reused after Msg can be reported.
+func acquireEvent(capacity int) *Event {
+	if debugMode {
+		return &Event{metadata: make(map[string]interface{}, capacity), capacity: capacity}
+	}
+	event := eventPool.Get().(*Event)
+	if event.capacity < capacity {
+		event.metadata = make(map[string]interface{}, capacity)
+		event.capacity = capacity
+	}
+	event.pooled = true
+	return event
+}
+
+// releaseEvent recycles the event if it was acquired from the pool
+func releaseEvent(e *Event) {
+	if e.pooled {
+		recycleEvent(e)
+	}
+}
+
+// recycleEvent clears the event and puts it back in the pool
+func recycleEvent(e *Event) {
+	metadata, capacity := e.metadata, e.capacity
+	clear(metadata)
+	*e = Event{metadata: metadata, capacity: capacity}
+	eventPool.Put(e)
+}
+
+// acquireBuffer returns a recycled formatting buffer
+func acquireBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+// releaseBuffer recycles the buffer holding data
+func releaseBuffer(buffer *[]byte, data []byte) {
+	if data == nil {
+		// formatting failed, the buffer was left untouched
+		bufferPool.Put(buffer)
+		return
+	}
+	if cap(data) > maxPooledBufferSize {
+		return
+	}
+	*buffer = data[:0]
+	bufferPool.Put(buffer)
+}
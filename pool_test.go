@@ -0,0 +1,30 @@
+package gologger
+
+import "testing"
+
+func TestDefaultFieldCapacity(t *testing.T) {
+	l, _ := newTestLogger()
+	l.SetDefaultFieldCapacity(32)
+
+	for i := 0; i < 3; i++ {
+		event := l.Info()
+		if event.capacity < 32 {
+			t.Errorf("event allocated for %d items, want at least 32", event.capacity)
+		}
+		event.Msg("event")
+	}
+}
+
+func TestRecycledEventsAreCleared(t *testing.T) {
+	l, _ := newTestLogger()
+	l.Info().Str("host", "example.com").Force().Msg("first")
+
+	event := l.Info()
+	defer event.Msg("second")
+	if event.forced || event.message != "" {
+		t.Error("recycled event kept its state")
+	}
+	if _, ok := event.Field("host"); ok {
+		t.Error("recycled event kept its metadata")
+	}
+}
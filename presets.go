@@ -0,0 +1,81 @@
+package gologger
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// ServiceMode configures the default logger for running as a daemon or
+// service, with timestamps and no colors, all levels being written to the
+// platform service log:
+//   - on Linux, JSON events are sent to the systemd journal when stderr is
+//     connected to it, the fields becoming journal fields
+//   - on Windows, events are reported to the event log when the process has
+//     no console, as services do
+//   - otherwise JSON events are written to stderr
+//
+// SIGINT and SIGTERM are handled to flush the writers before the signal is
+// delivered again, terminating the process unless the program handles the
+// signal itself, in which case it receives it twice. The returned function
+// flushes the writers and stops handling the signals, it should be deferred
+// in main so that buffered events are not lost on shutdown. Fatal events
+// close the writers before exiting.
+func ServiceMode() (flush func()) {
+	f, w := serviceOutput(serviceName())
+	DefaultLogger.SetFormatter(f)
+	DefaultLogger.SetWriter(w)
+	DefaultLogger.SetTimestamp(true, levels.LevelFatal)
+
+	return DefaultLogger.flushOnSignals(os.Interrupt, syscall.SIGTERM)
+}
+
+// serviceName returns the program name identifying its events
+func serviceName() string {
+	return strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+}
+
+// flushOnSignals flushes the logger writers when one of the signals is
+// received before delivering it again. The returned function flushes the
+// writers and stops handling the signals.
+func (l *Logger) flushOnSignals(sigs ...os.Signal) (flush func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sigs...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			l.flush()
+			signal.Stop(signals)
+			reraise(sig)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+		l.flush()
+	}
+}
+
+// ContainerMode configures the default logger following the 12-factor
+// conventions: JSON events without colors are written to stdout only, each
+// tagged with stream=stdout, and the max level is read from LOG_LEVEL.
+func ContainerMode() {
+	DefaultLogger.SetFormatter(&formatter.JSON{})
+	DefaultLogger.SetWriter(writer.NewStdout())
+	DefaultLogger.SetField("stream", "stdout")
+	if level, err := levels.Parse(os.Getenv("LOG_LEVEL")); err == nil {
+		DefaultLogger.SetMaxLevel(level)
+	}
+}
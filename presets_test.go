@@ -0,0 +1,78 @@
+//go:build !windows
+
+package gologger
+
+import (
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// flushCounter counts the flushes of the logger writer
+type flushCounter struct {
+	bufferWriter
+	flushes atomic.Int32
+}
+
+func (f *flushCounter) Flush() error {
+	f.flushes.Add(1)
+	return nil
+}
+
+func TestFlushOnSignals(t *testing.T) {
+	// handle the signal as a program would so that delivering it again
+	// doesn't terminate the test
+	received := make(chan os.Signal, 2)
+	signal.Notify(received, syscall.SIGUSR1)
+	defer signal.Stop(received)
+
+	l, _ := newTestLogger()
+	w := &flushCounter{}
+	l.SetWriter(w)
+	flush := l.flushOnSignals(syscall.SIGUSR1)
+	defer flush()
+	l.Info().Msg("event")
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("signal delivered %d times, want 2", i)
+		}
+	}
+	if got := w.flushes.Load(); got != 1 {
+		t.Errorf("flushes = %d, want 1", got)
+	}
+
+	flush()
+	if got := w.flushes.Load(); got != 2 {
+		t.Errorf("flushes = %d, want 2", got)
+	}
+	if w.String() != "[INF] event" {
+		t.Errorf("output = %q", w.String())
+	}
+}
+
+func TestServiceOutputStderr(t *testing.T) {
+	t.Setenv("JOURNAL_STREAM", "")
+
+	_, w := serviceOutput("test")
+	stderr, ok := w.(*writer.Stderr)
+	if !ok {
+		t.Fatalf("writer = %T, want stderr outside of systemd", w)
+	}
+	for _, level := range []levels.Level{levels.LevelSilent, levels.LevelInfo, levels.LevelError} {
+		if stderr.Stream(level) != os.Stderr {
+			t.Errorf("%s events are not written to stderr", level)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gologger
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimitMode selects the behavior when the emission rate limit is exceeded
+type RateLimitMode int
+
+const (
+	// RateLimitDrop drops the events exceeding the rate
+	RateLimitDrop RateLimitMode = iota
+	// RateLimitBlock blocks the caller until the event can be emitted
+	RateLimitBlock
+)
+
+// SetRateLimit limits the events emitted by the logger to rate events per
+// second with bursts of up to burst events, protecting shared terminals and
+// remote collectors from log floods. Fatal events are never limited.
+// A rate <= 0 disables the limit.
+func (l *Logger) SetRateLimit(rate float64, burst int, mode RateLimitMode) {
+	if rate <= 0 {
+		l.rateLimiter = nil
+		return
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	l.rateLimiter = &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		mode:   mode,
+	}
+}
+
+// rateLimiter is a token bucket shared by all the events of a logger
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	mode   RateLimitMode
+}
+
+// allow returns true if the event can be emitted. In blocking mode a token
+// is reserved and the caller sleeps until it becomes available.
+func (r *rateLimiter) allow() bool {
+	r.mutex.Lock()
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	if r.mode == RateLimitDrop {
+		defer r.mutex.Unlock()
+		if r.tokens < 1 {
+			return false
+		}
+		r.tokens--
+		return true
+	}
+
+	r.tokens--
+	wait := time.Duration(-r.tokens / r.rate * float64(time.Second))
+	r.mutex.Unlock()
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return true
+}
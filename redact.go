@@ -0,0 +1,234 @@
+package gologger
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces the secrets removed by the built-in redactors
+const RedactedValue = "[REDACTED]"
+
+// Redactor scrubs secrets from the events before they are formatted
+type Redactor interface {
+	// Redact returns the value of the metadata item with the given key with
+	// its secrets masked. The message of the event is passed with an empty key.
+	Redact(key, value string) string
+}
+
+// AddRedactor registers a redactor on the logger. Redactors run on the
+// message and the string and error metadata values of every event before
+// hooks and formatters, so that secrets never reach any writer. Nested maps
+// and slices, such as Dict and Fields values, are redacted recursively with
+// the keys of the nested items.
+func (l *Logger) AddRedactor(redactor Redactor) {
+	l.redactors = append(l.redactors, redactor)
+}
+
+// redact runs the redactors of the logger on the event
+func (l *Logger) redact(event *Event) {
+	if len(l.redactors) == 0 {
+		return
+	}
+	event.message = l.redactString("", event.message)
+	for k, v := range event.metadata {
+		if redacted, changed := l.redactValue(k, v); changed {
+			event.metadata[k] = redacted
+		}
+	}
+}
+
+// redactValue returns the value of the metadata item with its secrets
+// masked and whether it changed. The maps and slices are copied when they
+// change, as they may be shared with the caller.
+func (l *Logger) redactValue(key string, v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case string:
+		redacted := l.redactString(key, v)
+		return redacted, redacted != v
+	case error:
+		value := v.Error()
+		redacted := l.redactString(key, value)
+		return redacted, redacted != value
+	case map[string]interface{}:
+		var copied map[string]interface{}
+		for k, item := range v {
+			redacted, changed := l.redactValue(k, item)
+			if !changed {
+				continue
+			}
+			if copied == nil {
+				copied = make(map[string]interface{}, len(v))
+				for k, item := range v {
+					copied[k] = item
+				}
+			}
+			copied[k] = redacted
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	case map[string]string:
+		var copied map[string]string
+		for k, item := range v {
+			redacted := l.redactString(k, item)
+			if redacted == item {
+				continue
+			}
+			if copied == nil {
+				copied = make(map[string]string, len(v))
+				for k, item := range v {
+					copied[k] = item
+				}
+			}
+			copied[k] = redacted
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	case []interface{}:
+		var copied []interface{}
+		for i, item := range v {
+			redacted, changed := l.redactValue(key, item)
+			if !changed {
+				continue
+			}
+			if copied == nil {
+				copied = append([]interface{}(nil), v...)
+			}
+			copied[i] = redacted
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	case []string:
+		var copied []string
+		for i, item := range v {
+			redacted := l.redactString(key, item)
+			if redacted == item {
+				continue
+			}
+			if copied == nil {
+				copied = append([]string(nil), v...)
+			}
+			copied[i] = redacted
+		}
+		if copied == nil {
+			return v, false
+		}
+		return copied, true
+	default:
+		return v, false
+	}
+}
+
+// redactString runs the redactors on the string value
+func (l *Logger) redactString(key, value string) string {
+	for _, redactor := range l.redactors {
+		value = redactor.Redact(key, value)
+	}
+	return value
+}
+
+// DefaultSecretPatterns matches common secrets: bearer tokens, AWS access
+// keys, GitHub tokens and api key/token/secret/password assignments
+var DefaultSecretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)bearer\s+[a-z0-9\-._~+/]+=*`),
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`(?i)\b(?:api[_-]?key|token|secret|password)\s*[:=]\s*["']?[^\s"'&]+`),
+}
+
+// PatternRedactor returns a redactor masking the matches of the patterns,
+// DefaultSecretPatterns are used when none is given
+func PatternRedactor(patterns ...*regexp.Regexp) Redactor {
+	if len(patterns) == 0 {
+		patterns = DefaultSecretPatterns
+	}
+	return &patternRedactor{patterns: patterns}
+}
+
+type patternRedactor struct {
+	patterns []*regexp.Regexp
+}
+
+func (r *patternRedactor) Redact(key, value string) string {
+	for _, pattern := range r.patterns {
+		value = pattern.ReplaceAllLiteralString(value, RedactedValue)
+	}
+	return value
+}
+
+// KeyRedactor returns a redactor masking the whole value of the metadata
+// items with the given keys, compared case-insensitively. Dotted keys, such
+// as the ones of slog groups, also match on their last part.
+func KeyRedactor(keys ...string) Redactor {
+	redactor := &keyRedactor{keys: make(map[string]struct{}, len(keys))}
+	for _, key := range keys {
+		redactor.keys[strings.ToLower(key)] = struct{}{}
+	}
+	return redactor
+}
+
+type keyRedactor struct {
+	keys map[string]struct{}
+}
+
+func (r *keyRedactor) Redact(key, value string) string {
+	if key == "" {
+		return value
+	}
+	key = strings.ToLower(key)
+	if _, ok := r.keys[key]; ok {
+		return RedactedValue
+	}
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		if _, ok := r.keys[key[i+1:]]; ok {
+			return RedactedValue
+		}
+	}
+	return value
+}
+
+// ValueRedactor is a redactor masking explicit values registered at
+// runtime, such as the current session token
+type ValueRedactor struct {
+	mutex  sync.RWMutex
+	values []string
+}
+
+var _ Redactor = &ValueRedactor{}
+
+// NewValueRedactor returns a new redactor masking the given values
+func NewValueRedactor(values ...string) *ValueRedactor {
+	r := &ValueRedactor{}
+	for _, value := range values {
+		r.Add(value)
+	}
+	return r
+}
+
+// Add registers a value to be masked, empty values are ignored
+func (r *ValueRedactor) Add(value string) {
+	if value == "" {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.values = append(r.values, value)
+}
+
+// Redact masks the registered values
+func (r *ValueRedactor) Redact(key, value string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, secret := range r.values {
+		value = strings.ReplaceAll(value, secret, RedactedValue)
+	}
+	return value
+}
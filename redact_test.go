@@ -0,0 +1,97 @@
+package gologger
+
+import (
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactValue(t *testing.T) {
+	l := &Logger{}
+	l.AddRedactor(PatternRedactor())
+	l.AddRedactor(KeyRedactor("password"))
+
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		expected interface{}
+	}{
+		{"string", "url", "https://x?token=abc123", "https://x?" + RedactedValue},
+		{"error", "error", errors.New("auth failed: Bearer abc.def"), "auth failed: " + RedactedValue},
+		{"key", "password", "hunter2", RedactedValue},
+		{"clean", "host", "example.com", "example.com"},
+		{"number", "password", 42, 42},
+		{
+			"nested map", "db",
+			map[string]interface{}{"password": "hunter2", "dsn": "token=abc123", "port": 5432},
+			map[string]interface{}{"password": RedactedValue, "dsn": RedactedValue, "port": 5432},
+		},
+		{
+			"deeply nested map", "config",
+			map[string]interface{}{"db": map[string]interface{}{"password": "hunter2"}},
+			map[string]interface{}{"db": map[string]interface{}{"password": RedactedValue}},
+		},
+		{
+			"string map", "headers",
+			map[string]string{"Authorization": "Bearer abc", "Accept": "*/*"},
+			map[string]string{"Authorization": RedactedValue, "Accept": "*/*"},
+		},
+		{
+			"string slice", "headers",
+			[]string{"Authorization: Bearer abc", "Accept: */*"},
+			[]string{"Authorization: " + RedactedValue, "Accept: */*"},
+		},
+		{
+			"interface slice", "items",
+			[]interface{}{"secret=abc", 1, map[string]interface{}{"password": "x"}},
+			[]interface{}{RedactedValue, 1, map[string]interface{}{"password": RedactedValue}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			redacted, _ := l.redactValue(test.key, test.value)
+			if !reflect.DeepEqual(redacted, test.expected) {
+				t.Errorf("got %#v, want %#v", redacted, test.expected)
+			}
+		})
+	}
+}
+
+func TestRedactDoesNotModifyCallerValues(t *testing.T) {
+	l, w := newTestLogger()
+	l.AddRedactor(PatternRedactor())
+	l.AddRedactor(KeyRedactor("password"))
+
+	credentials := map[string]interface{}{"user": "admin", "password": "hunter2"}
+	headers := []string{"Authorization: Bearer abc"}
+	l.Info().Any("credentials", credentials).Any("headers", headers).Msg("login")
+
+	if credentials["password"] != "hunter2" || headers[0] != "Authorization: Bearer abc" {
+		t.Error("the values of the caller were modified")
+	}
+	if output := w.String(); strings.Contains(output, "hunter2") || strings.Contains(output, "Bearer abc") {
+		t.Errorf("secret written in clear: %s", output)
+	}
+}
+
+func TestRedactEventFields(t *testing.T) {
+	l, w := newTestLogger()
+	l.AddRedactor(PatternRedactor())
+	l.AddRedactor(KeyRedactor("password"))
+
+	l.Info().Dict("db", "password", "hunter2", "dsn", "token=abc123").Msg("connected")
+	slog.New(NewSlogHandler(l, nil)).Info("connected", slog.Group("db", slog.String("password", "hunter2")))
+
+	output := w.String()
+	for _, secret := range []string{"hunter2", "abc123"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("%q written in clear:\n%s", secret, output)
+		}
+	}
+	if strings.Count(output, RedactedValue) != 3 {
+		t.Errorf("expected 3 redacted values:\n%s", output)
+	}
+}
@@ -0,0 +1,117 @@
+package gologger
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// rollup aggregates the events dropped by the samplers and the rate limit
+type rollup struct {
+	mutex  sync.Mutex
+	window time.Duration
+	groups map[rollupKey]*rollupGroup
+}
+
+type rollupKey struct {
+	level levels.Level
+	label string
+}
+
+type rollupGroup struct {
+	count  uint64
+	sample string
+}
+
+// EnableRollup aggregates the events dropped by the samplers and the rate
+// limit per level and label, and emits every window a rollup event per
+// group with the number of dropped events and a sample message, e.g.
+// "42 similar messages in last 1m0s", so that suppression never hides
+// problems entirely. Rollup events are logged at the level and with the
+// label of their group, bypassing sampling and rate limiting, except for
+// the results logged at the silent level which are summarized at the info
+// level. The returned function stops it.
+func (l *Logger) EnableRollup(window time.Duration) (stop func()) {
+	r := &rollup{window: window, groups: make(map[rollupKey]*rollupGroup)}
+	l.rollup.Store(r)
+
+	ticker := time.NewTicker(window)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				l.emitRollup(r)
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+			l.rollup.CompareAndSwap(r, nil)
+			l.emitRollup(r)
+		})
+	}
+}
+
+// recordDropped adds the event dropped by a sampler or the rate limit to
+// the rollup, if enabled
+func (l *Logger) recordDropped(event *Event) {
+	r := l.rollup.Load()
+	if r == nil {
+		return
+	}
+	label, _ := event.metadata["label"].(string)
+	key := rollupKey{level: event.level, label: label}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	group, ok := r.groups[key]
+	if !ok {
+		group = &rollupGroup{sample: event.message}
+		r.groups[key] = group
+	}
+	group.count++
+}
+
+// emitRollup logs a rollup event per group and resets the groups
+func (l *Logger) emitRollup(r *rollup) {
+	r.mutex.Lock()
+	groups := r.groups
+	r.groups = make(map[rollupKey]*rollupGroup)
+	r.mutex.Unlock()
+
+	keys := make([]rollupKey, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].level != keys[j].level {
+			return keys[i].level < keys[j].level
+		}
+		return keys[i].label < keys[j].label
+	})
+	for _, key := range keys {
+		group := groups[key]
+		level := key.level
+		if level == levels.LevelSilent {
+			// results are written to stdout, which is kept for the results
+			level = levels.LevelInfo
+		}
+		event := l.WithLevel(level).Force()
+		if key.label != "" {
+			event.Label(key.label)
+		}
+		event.Uint64("rollup_count", group.count).
+			Str("sample", group.sample).
+			Msg(fmt.Sprintf("%d similar messages in last %s", group.count, r.window))
+	}
+}
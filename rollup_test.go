@@ -0,0 +1,57 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestRollup(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    levels.Level
+		log      func(l *Logger, message string)
+		expected string
+	}{
+		{"info", levels.LevelInfo, func(l *Logger, message string) { l.Info().Msg(message) }, "[INF] 4 similar messages in last 1h0m0s"},
+		{"labeled", levels.LevelWarning, func(l *Logger, message string) { l.Warning().Label("SCAN").Msg(message) }, "[SCAN] 4 similar messages in last 1h0m0s"},
+		{"results", levels.LevelSilent, func(l *Logger, message string) { l.Print().Msg(message) }, "[INF] 4 similar messages in last 1h0m0s"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l, w := newTestLogger()
+			l.SetSampler(test.level, FirstThenEvery(2, 0))
+			stop := l.EnableRollup(time.Hour)
+			for i := 0; i < 6; i++ {
+				test.log(l, "message "+string(rune('0'+i)))
+			}
+			stop()
+
+			lines := w.Lines()
+			if len(lines) != 3 {
+				t.Fatalf("expected 2 events and a rollup, got %q", lines)
+			}
+			// the fields order is not deterministic
+			rollup := lines[2]
+			if !strings.HasPrefix(rollup, test.expected) || !strings.Contains(rollup, " rollup_count=4") || !strings.Contains(rollup, " sample=message 2") {
+				t.Errorf("got rollup %q, want %q with the count and sample", rollup, test.expected)
+			}
+		})
+	}
+}
+
+func TestRollupDisabled(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetSampler(levels.LevelInfo, FirstThenEvery(1, 0))
+	stop := l.EnableRollup(time.Hour)
+	stop()
+	l.Info().Msg("kept")
+	l.Info().Msg("dropped")
+	stop()
+
+	if output := w.String(); strings.Contains(output, "similar") {
+		t.Errorf("rollup emitted after stop:\n%s", output)
+	}
+}
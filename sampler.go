@@ -0,0 +1,201 @@
+package gologger
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// SamplingSeedEnv is the environment variable setting the seed of the
+// random samplers, so that two runs keep and drop the same events
+const SamplingSeedEnv = "GOLOGGER_SAMPLING_SEED"
+
+var samplingSeed atomic.Int64
+
+func init() {
+	seed, err := strconv.ParseInt(os.Getenv(SamplingSeedEnv), 10, 64)
+	if err != nil {
+		seed = time.Now().UnixNano()
+	}
+	samplingSeed.Store(seed)
+}
+
+// SamplingSeed returns the seed of the random samplers, to be reported in
+// bug reports and passed back with GOLOGGER_SAMPLING_SEED to reproduce a run
+func SamplingSeed() int64 {
+	return samplingSeed.Load()
+}
+
+// SetSamplingSeed sets the seed of the random samplers created afterwards
+func SetSamplingSeed(seed int64) {
+	samplingSeed.Store(seed)
+}
+
+// Sampler decides whether an event should be logged
+type Sampler interface {
+	// Sample returns true if the event should be logged
+	Sample(event *Event) bool
+}
+
+// SetSampler sets the sampler for events of the given level.
+// A nil sampler disables sampling for the level.
+func (l *Logger) SetSampler(level levels.Level, sampler Sampler) {
+	if l.samplers == nil {
+		l.samplers = make(map[levels.Level]Sampler)
+	}
+	if sampler == nil {
+		delete(l.samplers, level)
+		return
+	}
+	l.samplers[level] = sampler
+}
+
+// sample returns true if the event passes the sampler of its level,
+// counting the suppressed events otherwise. Fatal events are never sampled.
+func (l *Logger) sample(event *Event) bool {
+	if event.level == levels.LevelFatal {
+		return true
+	}
+	sampler, ok := l.samplers[event.level]
+	if !ok || sampler.Sample(event) {
+		return true
+	}
+	l.suppressed.inc(event.level)
+	l.recordDropped(event)
+	return false
+}
+
+// Suppressed returns the number of events suppressed by samplers per level
+// since the last call and resets the counters
+func (l *Logger) Suppressed() map[string]uint64 {
+	return l.suppressed.reset()
+}
+
+// ReportSuppressed starts emitting an info event with the number of events
+// suppressed by samplers every interval. The returned function stops it.
+func (l *Logger) ReportSuppressed(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				suppressed := l.Suppressed()
+				if len(suppressed) == 0 {
+					continue
+				}
+				event := l.Info()
+				for level, count := range suppressed {
+					event.Uint64(level, count)
+				}
+				event.Int64("seed", SamplingSeed())
+				event.Msg("suppressed events by sampling")
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
+
+// EveryN returns a sampler keeping one event out of n
+func EveryN(n uint64) Sampler {
+	return &everyNSampler{n: n}
+}
+
+type everyNSampler struct {
+	n       uint64
+	counter atomic.Uint64
+}
+
+func (s *everyNSampler) Sample(event *Event) bool {
+	if s.n <= 1 {
+		return true
+	}
+	return (s.counter.Add(1)-1)%s.n == 0
+}
+
+// FirstThenEvery returns a sampler keeping the first events and then one
+// event out of thereafter
+func FirstThenEvery(first, thereafter uint64) Sampler {
+	return &firstThenEverySampler{first: first, thereafter: thereafter}
+}
+
+type firstThenEverySampler struct {
+	first      uint64
+	thereafter uint64
+	counter    atomic.Uint64
+}
+
+func (s *firstThenEverySampler) Sample(event *Event) bool {
+	count := s.counter.Add(1)
+	if count <= s.first {
+		return true
+	}
+	if s.thereafter == 0 {
+		return false
+	}
+	return (count-s.first-1)%s.thereafter == 0
+}
+
+// Random returns a sampler keeping events with the given probability
+// (between 0 and 1). It is seeded with SamplingSeed so that a run with the
+// same seed and the same sequence of events keeps the same events.
+func Random(probability float64) Sampler {
+	return &randomSampler{probability: probability, rand: rand.New(rand.NewSource(SamplingSeed()))}
+}
+
+type randomSampler struct {
+	mutex       sync.Mutex
+	probability float64
+	rand        *rand.Rand
+}
+
+func (s *randomSampler) Sample(event *Event) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.rand.Float64() < s.probability
+}
+
+// Burst returns a token bucket sampler allowing bursts of up to burst
+// events and refilling at rate events per second
+func Burst(rate float64, burst int) Sampler {
+	return &burstSampler{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+type burstSampler struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (s *burstSampler) Sample(event *Event) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.last).Seconds() * s.rate
+	if s.tokens > s.burst {
+		s.tokens = s.burst
+	}
+	s.last = now
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
@@ -0,0 +1,96 @@
+package gologger
+
+import (
+	"testing"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// sampled returns the decisions of the sampler for n events, x for the kept
+// events and . for the dropped ones
+func sampled(sampler Sampler, n int) string {
+	decisions := make([]byte, n)
+	for i := range decisions {
+		decisions[i] = '.'
+		if sampler.Sample(nil) {
+			decisions[i] = 'x'
+		}
+	}
+	return string(decisions)
+}
+
+func TestEveryN(t *testing.T) {
+	if got := sampled(EveryN(3), 10); got != "x..x..x..x" {
+		t.Errorf("EveryN(3) kept %s", got)
+	}
+	for _, n := range []uint64{0, 1} {
+		if got := sampled(EveryN(n), 5); got != "xxxxx" {
+			t.Errorf("EveryN(%d) kept %s", n, got)
+		}
+	}
+}
+
+func TestFirstThenEvery(t *testing.T) {
+	if got := sampled(FirstThenEvery(2, 3), 10); got != "xxx..x..x." {
+		t.Errorf("FirstThenEvery(2, 3) kept %s", got)
+	}
+	if got := sampled(FirstThenEvery(4, 0), 10); got != "xxxx......" {
+		t.Errorf("FirstThenEvery(4, 0) kept %s", got)
+	}
+}
+
+func TestBurst(t *testing.T) {
+	// without refill only the burst is allowed
+	if got := sampled(Burst(0, 3), 10); got != "xxx......." {
+		t.Errorf("Burst(0, 3) kept %s", got)
+	}
+}
+
+func TestSuppressed(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetSampler(levels.LevelInfo, FirstThenEvery(1, 0))
+
+	for i := 0; i < 3; i++ {
+		l.Info().Msg("info")
+	}
+	l.Warning().Msg("warning")
+
+	if lines := w.Lines(); len(lines) != 2 {
+		t.Errorf("expected an info and a warning event, got %q", lines)
+	}
+	suppressed := l.Suppressed()
+	if len(suppressed) != 1 || suppressed[levels.LevelInfo.String()] != 2 {
+		t.Errorf("suppressed = %v, want 2 info events", suppressed)
+	}
+	if suppressed := l.Suppressed(); len(suppressed) != 0 {
+		t.Errorf("counters not reset: %v", suppressed)
+	}
+}
+
+func TestForceBypassesSampling(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetSampler(levels.LevelInfo, FirstThenEvery(0, 0))
+
+	l.Info().Msg("dropped")
+	l.Info().Force().Msg("forced")
+
+	if output := w.String(); output != "[INF] forced" {
+		t.Errorf("got %q, want only the forced event", output)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	seed := SamplingSeed()
+	defer SetSamplingSeed(seed)
+
+	SetSamplingSeed(42)
+	if first, second := sampled(Random(0.5), 100), sampled(Random(0.5), 100); first != second {
+		t.Errorf("samplers with the same seed diverged:\n%s\n%s", first, second)
+	}
+	if got := sampled(Random(0), 5); got != "....." {
+		t.Errorf("Random(0) kept %s", got)
+	}
+	if got := sampled(Random(1), 5); got != "xxxxx" {
+		t.Errorf("Random(1) kept %s", got)
+	}
+}
@@ -0,0 +1,65 @@
+package gologger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// rotator is implemented by writers able to start a new output file
+type rotator interface {
+	Rotate() error
+}
+
+// Segment starts a new log segment named after a scan phase (e.g.
+// "bruteforce-phase"): file writers supporting it are rotated and a marker
+// event labeled SEG is written with the segment name and index, making
+// multi-hour logs easier to navigate.
+func (l *Logger) Segment(name string) {
+	index := l.segments.Add(1)
+	_, w := l.output()
+	if r, ok := w.(rotator); ok {
+		_ = r.Rotate()
+	}
+	for _, sink := range l.loadSinks() {
+		if r, ok := sink.Writer.(rotator); ok {
+			_ = r.Rotate()
+		}
+	}
+	newEventWithLevelAndLogger(levels.LevelInfo, l).
+		Label("SEG").
+		Str("segment", name).
+		Uint64("segment_index", index).
+		Force().
+		Msgf("segment %s started", name)
+}
+
+// Segment starts a new log segment on the default logger
+func Segment(name string) {
+	DefaultLogger.Segment(name)
+}
+
+// SegmentEvery starts a new segment every interval, named after the start
+// time of the segment. The returned function stops it.
+func (l *Logger) SegmentEvery(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case t := <-ticker.C:
+				l.Segment(t.Format("2006-01-02T15:04:05"))
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package gologger
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// SelfTest writes the detected terminal capabilities followed by one sample
+// line per level for each built-in formatter, so that users can verify their
+// terminal or log pipeline renders gologger output correctly.
+func SelfTest(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "terminal=%t color=%t width=%d\n",
+		writer.IsTerminal(os.Stderr), writer.SupportsColor(os.Stderr), writer.TerminalWidth())
+	if err != nil {
+		return err
+	}
+
+	formatters := []struct {
+		name      string
+		formatter formatter.Formatter
+	}{
+		{"cli", formatter.NewCLIWithColorMode(formatter.ColorAlways)},
+		{"cli-nocolor", formatter.NewCLI(true)},
+		{"json", &formatter.JSON{}},
+	}
+	for _, f := range formatters {
+		if _, err := fmt.Fprintf(w, "%s:\n", f.name); err != nil {
+			return err
+		}
+		for _, level := range levels.All() {
+			metadata := make(map[string]interface{})
+			if label, ok := labels[level]; ok {
+				metadata["label"] = label
+			}
+			data, err := f.formatter.Format(&formatter.LogEvent{
+				Message:  fmt.Sprintf("sample %s message", level),
+				Level:    level,
+				Metadata: metadata,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build linux
+
+package gologger
+
+import (
+	"os"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// serviceOutput sends the events to the journal when the process is run by
+// systemd with stderr connected to it, JSON to stderr otherwise
+func serviceOutput(name string) (formatter.Formatter, writer.Writer) {
+	if writer.IsJournalStream(os.Stderr) {
+		if w, err := writer.NewJournald(name); err == nil {
+			return &formatter.JSON{}, w
+		}
+	}
+	return &formatter.JSON{}, writer.NewStderr()
+}
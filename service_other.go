@@ -0,0 +1,14 @@
+//go:build !linux && !windows
+
+package gologger
+
+import (
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// serviceOutput writes JSON events to stderr, which service managers such
+// as launchd redirect to a file
+func serviceOutput(name string) (formatter.Formatter, writer.Writer) {
+	return &formatter.JSON{}, writer.NewStderr()
+}
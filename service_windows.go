@@ -0,0 +1,23 @@
+//go:build windows
+
+package gologger
+
+import (
+	"syscall"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+var getConsoleWindow = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleWindow")
+
+// serviceOutput reports the events to the event log when the process has
+// no console, as services do, JSON to stderr otherwise
+func serviceOutput(name string) (formatter.Formatter, writer.Writer) {
+	if console, _, _ := getConsoleWindow.Call(); console == 0 {
+		if w, err := writer.NewEventLog(name); err == nil {
+			return formatter.NewCLI(true), w
+		}
+	}
+	return &formatter.JSON{}, writer.NewStderr()
+}
@@ -0,0 +1,81 @@
+package gologger
+
+import (
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// Sink binds a formatter to a writer with its own max level, allowing a
+// logger to emit the same event in different formats to different outputs.
+type Sink struct {
+	Formatter formatter.Formatter
+	Writer    writer.Writer
+	MaxLevel  levels.Level
+}
+
+// NewSink returns a new sink writing events up to maxLevel
+func NewSink(formatter formatter.Formatter, writer writer.Writer, maxLevel levels.Level) *Sink {
+	return &Sink{Formatter: formatter, Writer: writer, MaxLevel: maxLevel}
+}
+
+// NewResultsSink returns a sink writing the results, events logged at the
+// silent level, to w with the CLI formatter. With dedupe the results are
+// written through a unique writer so that each line is written only once,
+// see writer.NewUnique.
+func NewResultsSink(w writer.Writer, dedupe bool, options writer.UniqueOptions) *Sink {
+	if dedupe {
+		w = writer.NewUnique(w, options)
+	}
+	return NewSink(formatter.NewCLI(true), w, levels.LevelSilent)
+}
+
+// AddSink registers an additional sink on the logger. Events are still
+// written to the logger formatter and writer as well. The sink formatter is
+// adjusted to the sink writer capabilities, see negotiateFormatter.
+func (l *Logger) AddSink(sink *Sink) {
+	l.outputMutex.Lock()
+	defer l.outputMutex.Unlock()
+
+	sink.Formatter = negotiateFormatter(sink.Formatter, sink.Writer)
+	// the slice is replaced rather than appended to so that the events
+	// being logged keep iterating over the previous one
+	sinks := make([]*Sink, len(l.sinks), len(l.sinks)+1)
+	copy(sinks, l.sinks)
+	l.sinks = append(sinks, sink)
+}
+
+// loadSinks returns the sinks of the logger, the slice must not be modified
+func (l *Logger) loadSinks() []*Sink {
+	l.outputMutex.RLock()
+	defer l.outputMutex.RUnlock()
+
+	return l.sinks
+}
+
+// negotiateFormatter adjusts the formatter to the capabilities declared by
+// the writer: the colors of the formatters in auto color mode are enabled
+// only when the writer supports them, and a missing formatter is picked
+// according to the writer JSON preference.
+func negotiateFormatter(f formatter.Formatter, w writer.Writer) formatter.Formatter {
+	capabilities, ok := w.(writer.Capabilities)
+	if !ok {
+		return f
+	}
+	if f == nil {
+		if capabilities.PrefersJSON() {
+			return &formatter.JSON{}
+		}
+		f = &formatter.CLI{Theme: formatter.DefaultColorTheme}
+	}
+	// explicit color modes are kept, and the formatter may be in use by the
+	// logger so a copy of it is adjusted
+	if c, ok := f.(*formatter.CLI); ok && c.ColorMode() == formatter.ColorAuto {
+		if useColors := capabilities.SupportsColor(); c.NoUseColors == useColors {
+			c = c.Clone()
+			c.NoUseColors = !useColors
+			return c
+		}
+	}
+	return f
+}
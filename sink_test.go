@@ -0,0 +1,127 @@
+package gologger
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// TestReconfigureWhileLogging is meant to be run with -race
+func TestReconfigureWhileLogging(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetFormatter(formatter.NewCLI(false))
+	l.AddSink(NewSink(formatter.NewCLI(false), &bufferWriter{}, l.loadMaxLevel()))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			l.Info().Label("RUN").Int("i", i).Msg("event")
+			l.VerbosityN(2).Msg("verbose event")
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		l.SetWriter(writer.NewRing(16))
+		l.SetWriter(w)
+		l.SetColorMode(formatter.ColorAlways)
+		l.SetVerbosity(i % 3)
+	}
+	wg.Wait()
+}
+
+// TestAddSinkWhileLogging is meant to be run with -race
+func TestAddSinkWhileLogging(t *testing.T) {
+	l, _ := newTestLogger()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			l.Info().Int("i", i).Msg("event")
+			_ = l.Stats()
+		}
+	}()
+	sinks := make([]*bufferWriter, 20)
+	for i := range sinks {
+		sinks[i] = &bufferWriter{}
+		l.AddSink(NewSink(formatter.NewCLI(true), sinks[i], levels.LevelInfo))
+	}
+	wg.Wait()
+
+	l.Info().Msg("last")
+	for i, sink := range sinks {
+		if lines := sink.Lines(); len(lines) == 0 || lines[len(lines)-1] != "[INF] last" {
+			t.Errorf("sink %d got %q, want the last event", i, lines)
+		}
+	}
+}
+
+// capabilitiesWriter is a writer declaring its color support
+type capabilitiesWriter struct {
+	bufferWriter
+	color bool
+}
+
+func (w *capabilitiesWriter) SupportsColor() bool { return w.color }
+func (w *capabilitiesWriter) IsTerminal() bool    { return w.color }
+func (w *capabilitiesWriter) PrefersJSON() bool   { return false }
+
+func TestNegotiateFormatterColors(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter func() *formatter.CLI
+		color     bool
+		colors    bool
+	}{
+		{"auto on color writer", func() *formatter.CLI { return formatter.NewCLI(false) }, true, true},
+		{"auto on plain writer", func() *formatter.CLI { return formatter.NewCLI(false) }, false, false},
+		{"disabled on color writer", func() *formatter.CLI { return formatter.NewCLI(true) }, true, false},
+		{"never on color writer", func() *formatter.CLI { return formatter.NewCLIWithColorMode(formatter.ColorNever) }, true, false},
+		{"always on plain writer", func() *formatter.CLI { return formatter.NewCLIWithColorMode(formatter.ColorAlways) }, false, true},
+		{"literal without colors", func() *formatter.CLI { return &formatter.CLI{NoUseColors: true} }, true, false},
+		{"literal with colors", func() *formatter.CLI { return &formatter.CLI{} }, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := test.formatter()
+			noUseColors := f.NoUseColors
+			negotiated := negotiateFormatter(f, &capabilitiesWriter{color: test.color}).(*formatter.CLI)
+			if negotiated.NoUseColors == test.colors {
+				t.Errorf("colors = %v, want %v", !negotiated.NoUseColors, test.colors)
+			}
+			if f.NoUseColors != noUseColors {
+				t.Error("the formatter was modified")
+			}
+		})
+	}
+}
+
+func TestAddSinkKeepsExplicitColorMode(t *testing.T) {
+	l, _ := newTestLogger()
+	w := &capabilitiesWriter{color: true}
+	l.AddSink(NewSink(formatter.NewCLI(true), w, levels.LevelInfo))
+	l.Error().Msg("failure")
+	if output := w.String(); output != "[ERR] failure" {
+		t.Errorf("unexpected sink output %q", output)
+	}
+}
+
+func TestSetColorModeAutoUsesWriter(t *testing.T) {
+	// stderr is considered as supporting colors
+	t.Setenv("FORCE_COLOR", "1")
+
+	l, _ := newTestLogger()
+	w := &capabilitiesWriter{color: false}
+	l.SetWriter(w)
+	l.SetColorMode(formatter.ColorAlways)
+	l.SetColorMode(formatter.ColorAuto)
+	l.Error().Msg("failure")
+	if output := w.String(); output != "[ERR] failure" {
+		t.Errorf("unexpected output %q", output)
+	}
+}
@@ -0,0 +1,194 @@
+package gologger
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// SlogLevelTrace is the slog level mapped to levels.LevelTrace
+const SlogLevelTrace = slog.LevelDebug - 4
+
+// SlogHandler is a slog.Handler writing the records through a gologger
+// logger, so that code using log/slog gets the same output as the native
+// API. Groups are added as nested maps, which the JSON formatter writes as
+// objects.
+type SlogHandler struct {
+	logger  *Logger
+	options SlogHandlerOptions
+	attrs   []slog.Attr
+	groups  []string
+}
+
+var _ slog.Handler = &SlogHandler{}
+
+// SlogHandlerOptions are the options of the slog handler
+type SlogHandlerOptions struct {
+	// Inner is a handler the records are also forwarded to (e.g. a slog
+	// JSON handler writing to a file), acting as a tee at the slog layer.
+	// Its own level is honored.
+	Inner slog.Handler
+}
+
+// NewSlogHandler returns a slog handler writing the records with the logger,
+// DefaultLogger being used if nil
+func NewSlogHandler(l *Logger, options *SlogHandlerOptions) *SlogHandler {
+	if l == nil {
+		l = DefaultLogger
+	}
+	h := &SlogHandler{logger: l}
+	if options != nil {
+		h.options = *options
+	}
+	return h
+}
+
+// Enabled reports whether the logger or the inner handler handles the level
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.logger.isLevelEnabled(fromSlogLevel(level)) {
+		return true
+	}
+	return h.options.Inner != nil && h.options.Inner.Enabled(ctx, level)
+}
+
+// Handle logs the record with the logger and forwards it to the inner handler
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	level := fromSlogLevel(record.Level)
+	if h.logger.isLevelEnabled(level) {
+		h.log(ctx, level, record)
+	}
+	if h.options.Inner != nil && h.options.Inner.Enabled(ctx, record.Level) {
+		return h.options.Inner.Handle(ctx, record.Clone())
+	}
+	return nil
+}
+
+func (h *SlogHandler) log(ctx context.Context, level levels.Level, record slog.Record) {
+	event := newEventWithLevelAndLogger(level, h.logger)
+	event.setLevelMetadata(level)
+	if !record.Time.IsZero() {
+		event.time = record.Time
+		if _, ok := event.metadata["timestamp"]; ok {
+			event.TimeStamp()
+		}
+	}
+	event.Ctx(ctx)
+	for _, attr := range h.attrs {
+		addSlogAttr(event.metadata, attr)
+	}
+	if len(h.groups) == 0 {
+		record.Attrs(func(attr slog.Attr) bool {
+			addSlogAttr(event.metadata, attr)
+			return true
+		})
+	} else if record.NumAttrs() > 0 {
+		attrs := make([]slog.Attr, 0, record.NumAttrs())
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs = append(attrs, attr)
+			return true
+		})
+		for _, attr := range h.inGroups(attrs) {
+			addSlogAttr(event.metadata, attr)
+		}
+	}
+	root := event.logger
+	if root.callerInfo && level.Rank() >= root.callerMinLevel.Rank() && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		event.metadata["caller"] = filepath.Base(filepath.Dir(frame.File)) + "/" + filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		event.metadata["function"] = frame.Function
+	}
+	event.message = record.Message
+	root.Log(event)
+}
+
+// WithAttrs returns a handler adding the attributes to the records
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	clone := *h
+	clone.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	clone.attrs = append(clone.attrs, h.attrs...)
+	// attributes are stored in the groups open at the time they were added
+	clone.attrs = append(clone.attrs, h.inGroups(attrs)...)
+	if h.options.Inner != nil {
+		clone.options.Inner = h.options.Inner.WithAttrs(attrs)
+	}
+	return &clone
+}
+
+// WithGroup returns a handler qualifying the following attributes with name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(h.groups[:len(h.groups):len(h.groups)], name)
+	if h.options.Inner != nil {
+		clone.options.Inner = h.options.Inner.WithGroup(name)
+	}
+	return &clone
+}
+
+// inGroups nests the attributes in the groups of the handler
+func (h *SlogHandler) inGroups(attrs []slog.Attr) []slog.Attr {
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{{Key: h.groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+	return attrs
+}
+
+// addSlogAttr adds the attribute to the metadata, groups being added as
+// nested maps merged with the existing ones. Empty groups are ignored and
+// the attributes of groups without a key are inlined.
+func addSlogAttr(metadata map[string]interface{}, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() != slog.KindGroup {
+		if attr.Key != "" {
+			metadata[attr.Key] = slogValue(value)
+		}
+		return
+	}
+	children := value.Group()
+	if len(children) == 0 {
+		return
+	}
+	if attr.Key == "" {
+		for _, child := range children {
+			addSlogAttr(metadata, child)
+		}
+		return
+	}
+	// the existing map may be shared with other events, it is copied
+	existing, _ := metadata[attr.Key].(map[string]interface{})
+	group := make(map[string]interface{}, len(existing)+len(children))
+	for k, v := range existing {
+		group[k] = v
+	}
+	for _, child := range children {
+		addSlogAttr(group, child)
+	}
+	metadata[attr.Key] = group
+}
+
+// fromSlogLevel maps a slog level to the closest gologger level
+func fromSlogLevel(level slog.Level) levels.Level {
+	switch {
+	case level >= slog.LevelError:
+		return levels.LevelError
+	case level >= slog.LevelWarn:
+		return levels.LevelWarning
+	case level >= slog.LevelInfo:
+		return levels.LevelInfo
+	case level >= slog.LevelDebug:
+		return levels.LevelDebug
+	case level > SlogLevelTrace:
+		return levels.LevelVerbose
+	default:
+		return levels.LevelTrace
+	}
+}
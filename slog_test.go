@@ -0,0 +1,86 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/formatter"
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestSlogHandler(t *testing.T) {
+	l, w := newTestLogger()
+	logger := slog.New(NewSlogHandler(l, nil))
+
+	logger.Debug("debug")
+	logger.Info("info", "status", 200)
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Log(context.Background(), SlogLevelTrace, "above the level")
+
+	want := `[DBG] debug
+[INF] info status=200
+[WRN] warn
+[ERR] error`
+	if got := w.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSlogHandlerGroups(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetFormatter(&formatter.JSON{})
+	logger := slog.New(NewSlogHandler(l, nil))
+
+	logger.With("scan", "s1").WithGroup("http").With("method", "GET").
+		Info("request", slog.Group("response", "status", 200), slog.Group("empty"), slog.Group("", "inline", true))
+	logger.WithGroup("unused").Info("no attributes")
+
+	want := []string{
+		`"level":"INF","msg":"request","http":{"inline":true,"method":"GET","response":{"status":200}},"scan":"s1"}`,
+		`"level":"INF","msg":"no attributes"}`,
+	}
+	lines := w.Lines()
+	if len(lines) != len(want) {
+		t.Fatalf("got %q", lines)
+	}
+	for i, line := range lines {
+		// the events start with their timestamp
+		if !strings.HasSuffix(line, ","+want[i]) {
+			t.Errorf("got  %s\nwant %s", line, want[i])
+		}
+	}
+}
+
+func TestSlogHandlerInner(t *testing.T) {
+	l, w := newTestLogger()
+	l.SetMaxLevel(levels.LevelWarning)
+	var buffer bytes.Buffer
+	inner := slog.NewJSONHandler(&buffer, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	logger := slog.New(NewSlogHandler(l, &SlogHandlerOptions{Inner: inner})).WithGroup("http").With("method", "GET")
+
+	logger.Debug("inner only")
+	logger.Warn("both", "status", 503)
+	logger.Log(context.Background(), SlogLevelTrace, "neither")
+
+	if lines := w.Lines(); len(lines) != 1 || !strings.HasPrefix(lines[0], "[WRN] both ") {
+		t.Errorf("logger got %q, want the warning only", lines)
+	}
+	want := `{"level":"DEBUG","msg":"inner only","http":{"method":"GET"}}
+{"level":"WARN","msg":"both","http":{"method":"GET","status":503}}
+`
+	if got := buffer.String(); got != want {
+		t.Errorf("inner handler got\n%s\nwant\n%s", got, want)
+	}
+}
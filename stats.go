@@ -0,0 +1,151 @@
+package gologger
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Stats is a snapshot of the logging counters of a logger
+type Stats struct {
+	// Level is the current max level of the logger
+	Level string `json:"level"`
+	// Counts is the number of events logged per level
+	Counts map[string]uint64 `json:"counts"`
+	// Labels is the number of events logged per label, including custom labels
+	Labels map[string]uint64 `json:"labels"`
+	// Dropped is the number of events dropped by the rate limit and the writers
+	Dropped uint64 `json:"dropped"`
+	// WriteErrors is the number of events which could not be formatted
+	WriteErrors uint64 `json:"write_errors"`
+	// QueueDepth is the number of events waiting to be written
+	QueueDepth int `json:"queue_depth"`
+}
+
+// dropCounter is implemented by writers which may drop events
+type dropCounter interface {
+	Dropped() uint64
+}
+
+// queueDepther is implemented by writers which queue events
+type queueDepther interface {
+	QueueDepth() int
+}
+
+// counters keeps the number of logged events per level
+type counters struct {
+	mutex  sync.Mutex
+	levels map[levels.Level]uint64
+}
+
+func (c *counters) inc(level levels.Level) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.levels == nil {
+		c.levels = make(map[levels.Level]uint64)
+	}
+	c.levels[level]++
+}
+
+func (c *counters) snapshot() map[string]uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	counts := make(map[string]uint64, len(c.levels))
+	for level, count := range c.levels {
+		counts[level.String()] = count
+	}
+	return counts
+}
+
+// reset returns the counters and resets them
+func (c *counters) reset() map[string]uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	counts := make(map[string]uint64, len(c.levels))
+	for level, count := range c.levels {
+		counts[level.String()] = count
+	}
+	c.levels = nil
+	return counts
+}
+
+// labelCounters keeps the number of logged events per label
+type labelCounters struct {
+	mutex  sync.Mutex
+	labels map[string]uint64
+}
+
+func (c *labelCounters) inc(label string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.labels == nil {
+		c.labels = make(map[string]uint64)
+	}
+	c.labels[label]++
+}
+
+func (c *labelCounters) snapshot() map[string]uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	counts := make(map[string]uint64, len(c.labels))
+	for label, count := range c.labels {
+		counts[label] = count
+	}
+	return counts
+}
+
+// LabelCount is the number of events logged with a label
+type LabelCount struct {
+	Label string `json:"label"`
+	Count uint64 `json:"count"`
+}
+
+// TopN returns the n labels with the most logged events in descending
+// order, useful to find which template or module is the noisiest
+func (s Stats) TopN(n int) []LabelCount {
+	top := make([]LabelCount, 0, len(s.Labels))
+	for label, count := range s.Labels {
+		top = append(top, LabelCount{Label: label, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Label < top[j].Label
+	})
+	if n >= 0 && n < len(top) {
+		top = top[:n]
+	}
+	return top
+}
+
+// Stats returns a snapshot of the logger counters
+func (l *Logger) Stats() Stats {
+	stats := Stats{
+		Level:       l.loadMaxLevel().String(),
+		Counts:      l.counters.snapshot(),
+		Labels:      l.labelCounters.snapshot(),
+		Dropped:     l.rateDropped.Load(),
+		WriteErrors: l.writeErrors.Load(),
+	}
+	_, w := l.output()
+	writers := []interface{}{w}
+	for _, sink := range l.loadSinks() {
+		writers = append(writers, sink.Writer)
+	}
+	for _, w := range writers {
+		if dc, ok := w.(dropCounter); ok {
+			stats.Dropped += dc.Dropped()
+		}
+		if qd, ok := w.(queueDepther); ok {
+			stats.QueueDepth += qd.QueueDepth()
+		}
+	}
+	return stats
+}
@@ -0,0 +1,96 @@
+package gologger
+
+import (
+	"bytes"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// RedirectStdLog points the standard log package output at the logger, so
+// that the third-party libraries using log.Printf share the same output.
+// Each line is logged at the level, the prefix, date, time and file written
+// by the log package being parsed into the prefix field, the event time and
+// the caller field. The returned function restores the previous output.
+func RedirectStdLog(l *Logger, level levels.Level) (restore func()) {
+	std := log.Default()
+	previous := std.Writer()
+	w := &lineWriter{logger: l, level: level, stdLogger: std}
+	std.SetOutput(w)
+	return func() {
+		std.SetOutput(previous)
+		_ = w.Close()
+	}
+}
+
+// parseStdLogHeader parses the header written by the standard logger
+// according to its current flags into the event and returns the message
+func parseStdLogHeader(event *Event, std *log.Logger, line []byte) []byte {
+	flags, prefix := std.Flags(), []byte(std.Prefix())
+	if flags&log.Lmsgprefix == 0 {
+		line = trimStdLogPrefix(event, line, prefix)
+	}
+
+	layout := ""
+	if flags&log.Ldate != 0 {
+		layout = "2006/01/02 "
+	}
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		layout += "15:04:05"
+		if flags&log.Lmicroseconds != 0 {
+			layout += ".000000"
+		}
+		layout += " "
+	}
+	if layout != "" && len(line) >= len(layout) {
+		location := time.Local
+		if flags&log.LUTC != 0 {
+			location = time.UTC
+		}
+		if t, err := time.ParseInLocation(layout, string(line[:len(layout)]), location); err == nil {
+			if flags&log.Ldate != 0 {
+				event.time = t
+				if _, ok := event.metadata["timestamp"]; ok {
+					event.TimeStamp()
+				}
+			}
+			line = line[len(layout):]
+		}
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if i := bytes.Index(line, []byte(": ")); i > 0 {
+			var file string
+			switch {
+			case flags&log.Lshortfile == 0:
+				// full paths are shortened like the caller info of the events
+				file = string(line[:i])
+				file = filepath.Base(filepath.Dir(file)) + "/" + filepath.Base(file)
+			case event.logger.interner != nil:
+				file = event.logger.interner.internBytes(line[:i])
+			default:
+				file = string(line[:i])
+			}
+			event.metadata["caller"] = file
+			line = line[i+2:]
+		}
+	}
+
+	if flags&log.Lmsgprefix != 0 {
+		line = trimStdLogPrefix(event, line, prefix)
+	}
+	return line
+}
+
+// trimStdLogPrefix removes the prefix from the line, adding it to the event
+func trimStdLogPrefix(event *Event, line, prefix []byte) []byte {
+	if len(prefix) == 0 || !bytes.HasPrefix(line, prefix) {
+		return line
+	}
+	if name := bytes.TrimSpace(bytes.TrimRight(prefix, ": ")); len(name) > 0 {
+		event.metadata["prefix"] = string(name)
+	}
+	return line[len(prefix):]
+}
@@ -0,0 +1,122 @@
+package gologger
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// StreamPolicy defines which standard stream the events of each level may
+// be written to, protecting `tool | jq` pipelines from diagnostics leaking
+// into the data written on stdout
+type StreamPolicy int
+
+// Available stream policies
+const (
+	// StreamPolicyNone disables the stream assertions
+	StreamPolicyNone StreamPolicy = iota
+	// StreamPolicyStrict requires silent events on stdout and all the
+	// other events on stderr
+	StreamPolicyStrict
+	// StreamPolicyStdout requires all the events on stdout, as in ContainerMode
+	StreamPolicyStdout
+)
+
+// StreamViolation is the panic value used when an event is written to a
+// stream forbidden by the stream policy
+type StreamViolation struct {
+	Level  levels.Level
+	Stream string
+}
+
+// Error returns the violation message
+func (v *StreamViolation) Error() string {
+	return fmt.Sprintf("gologger: %s event written to %s", v.Level, v.Stream)
+}
+
+// SetStreamPolicy enables the runtime assertion mode: writing an event to a
+// standard stream forbidden by the policy panics with a *StreamViolation.
+// Only writers implementing writer.Streamer are checked.
+func (l *Logger) SetStreamPolicy(policy StreamPolicy) {
+	l.streamPolicy = policy
+}
+
+// checkStream panics if the writer prints the level to a forbidden stream
+func (l *Logger) checkStream(w writer.Writer, level levels.Level) {
+	streamer, ok := w.(writer.Streamer)
+	if !ok {
+		return
+	}
+	stream := streamer.Stream(level)
+	var allowed *os.File
+	switch l.streamPolicy {
+	case StreamPolicyStrict:
+		allowed = os.Stderr
+		if level == levels.LevelSilent {
+			allowed = os.Stdout
+		}
+	case StreamPolicyStdout:
+		allowed = os.Stdout
+	default:
+		return
+	}
+	if stream != allowed {
+		panic(&StreamViolation{Level: level, Stream: streamName(stream)})
+	}
+}
+
+func streamName(f *os.File) string {
+	switch f {
+	case os.Stdout:
+		return "stdout"
+	case os.Stderr:
+		return "stderr"
+	default:
+		return f.Name()
+	}
+}
+
+// CaptureStreams runs fn with os.Stdout and os.Stderr redirected and
+// returns what has been written on each of them, allowing integration tests
+// to verify that only data reaches stdout. It must not be called
+// concurrently with other code relying on the standard streams.
+func CaptureStreams(fn func()) (stdout, stderr []byte, err error) {
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		stdoutReader.Close()
+		stdoutWriter.Close()
+		return nil, nil, err
+	}
+
+	stdoutDone := readAllAsync(stdoutReader)
+	stderrDone := readAllAsync(stderrReader)
+
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdoutWriter, stderrWriter
+	defer func() {
+		os.Stdout, os.Stderr = originalStdout, originalStderr
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		stdout, stderr = <-stdoutDone, <-stderrDone
+	}()
+
+	fn()
+	return nil, nil, nil
+}
+
+func readAllAsync(r io.ReadCloser) <-chan []byte {
+	done := make(chan []byte, 1)
+	go func() {
+		defer r.Close()
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+	return done
+}
@@ -0,0 +1,79 @@
+package gologger
+
+import "github.com/projectdiscovery/gologger/levels"
+
+// KV adds alternating key/value pairs to the event metadata, like the
+// sugared logger of zap. A trailing key without value is ignored.
+func (e *Event) KV(pairs ...interface{}) *Event {
+	e.checkNotEmitted()
+	e.addPairs(pairs)
+	return e
+}
+
+// Infow logs an info message with alternating key/value pairs
+func (l *Logger) Infow(msg string, kv ...interface{}) {
+	event := newSugaredEvent(l, levels.LevelInfo, msg, kv)
+	event.setCaller()
+	l.Log(event)
+}
+
+// Warningw logs a warning message with alternating key/value pairs
+func (l *Logger) Warningw(msg string, kv ...interface{}) {
+	event := newSugaredEvent(l, levels.LevelWarning, msg, kv)
+	event.setCaller()
+	l.Log(event)
+}
+
+// Errorw logs an error message with alternating key/value pairs
+func (l *Logger) Errorw(msg string, kv ...interface{}) {
+	event := newSugaredEvent(l, levels.LevelError, msg, kv)
+	event.setCaller()
+	l.Log(event)
+}
+
+// Debugw logs a debug message with alternating key/value pairs
+func (l *Logger) Debugw(msg string, kv ...interface{}) {
+	event := newSugaredEvent(l, levels.LevelDebug, msg, kv)
+	event.setCaller()
+	l.Log(event)
+}
+
+// Infow logs an info message with alternating key/value pairs on the
+// default logger
+func Infow(msg string, kv ...interface{}) {
+	event := newSugaredEvent(DefaultLogger, levels.LevelInfo, msg, kv)
+	event.setCaller()
+	DefaultLogger.Log(event)
+}
+
+// Warningw logs a warning message with alternating key/value pairs on the
+// default logger
+func Warningw(msg string, kv ...interface{}) {
+	event := newSugaredEvent(DefaultLogger, levels.LevelWarning, msg, kv)
+	event.setCaller()
+	DefaultLogger.Log(event)
+}
+
+// Errorw logs an error message with alternating key/value pairs on the
+// default logger
+func Errorw(msg string, kv ...interface{}) {
+	event := newSugaredEvent(DefaultLogger, levels.LevelError, msg, kv)
+	event.setCaller()
+	DefaultLogger.Log(event)
+}
+
+// Debugw logs a debug message with alternating key/value pairs on the
+// default logger
+func Debugw(msg string, kv ...interface{}) {
+	event := newSugaredEvent(DefaultLogger, levels.LevelDebug, msg, kv)
+	event.setCaller()
+	DefaultLogger.Log(event)
+}
+
+func newSugaredEvent(l *Logger, level levels.Level, msg string, kv []interface{}) *Event {
+	event := newEventWithLevelAndLogger(level, l)
+	event.setLevelMetadata(level)
+	event.addPairs(kv)
+	event.message = msg
+	return event
+}
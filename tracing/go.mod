@@ -0,0 +1,21 @@
+module github.com/projectdiscovery/gologger/tracing
+
+go 1.21
+
+require (
+	github.com/projectdiscovery/gologger v1.1.38
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/klauspost/compress v1.17.4 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/projectdiscovery/utils v0.4.5 // indirect
+	go.opentelemetry.io/otel v1.24.0 // indirect
+	gopkg.in/djherbis/times.v1 v1.3.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/projectdiscovery/gologger => ../
@@ -0,0 +1,32 @@
+// Package tracing propagates the ids of the OpenTelemetry spans carried by
+// contexts into the gologger events, keeping the OpenTelemetry dependency
+// out of the core module.
+package tracing
+
+import (
+	"context"
+
+	"github.com/projectdiscovery/gologger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Extractor is a context extractor adding the trace_id and span_id of the
+// OpenTelemetry span carried by the context
+var Extractor gologger.ContextExtractor = gologger.ContextExtractorFunc(extract)
+
+// Register registers Extractor so that Event.Ctx adds the ids of the spans
+func Register() {
+	gologger.RegisterContextExtractor(Extractor)
+}
+
+// extract returns the trace and span ids of the span carried by ctx
+func extract(ctx context.Context) map[string]interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return map[string]interface{}{
+		"trace_id": spanContext.TraceID().String(),
+		"span_id":  spanContext.SpanID().String(),
+	}
+}
@@ -0,0 +1,30 @@
+package gologger
+
+import (
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+// Transient marks the event as a transient status message, such as a
+// progress line. Terminal writers supporting it display the event until
+// the next one overwrites it, while other writers get it at the verbose
+// level so that files are not polluted with status lines.
+func (e *Event) Transient() *Event {
+	e.checkNotEmitted()
+	e.transient = true
+	return e
+}
+
+// transientLevel returns the level a transient event is written at and
+// whether the writer displays it as transient
+func transientLevel(w writer.Writer, level levels.Level) (levels.Level, bool) {
+	if level == levels.LevelFatal {
+		return level, false
+	}
+	if _, ok := w.(writer.Transient); ok {
+		if c, ok := w.(writer.Capabilities); ok && c.IsTerminal() {
+			return level, true
+		}
+	}
+	return levels.LevelVerbose, false
+}
@@ -0,0 +1,65 @@
+package gologger
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Translator returns the message for the message id of an event, with the
+// event metadata as arguments which must not be modified. An empty result
+// keeps the original message.
+type Translator func(msgID string, args map[string]interface{}) string
+
+// SetTranslator sets the translator used to localize or standardize the
+// messages of the events having a message id. The id is kept in the
+// "msg_id" field so that machine outputs can rely on it.
+func (l *Logger) SetTranslator(translator Translator) {
+	l.translator = translator
+}
+
+// MsgID sets the stable message id of the event used for translation
+func (e *Event) MsgID(id string) *Event {
+	e.checkNotEmitted()
+	e.metadata["msg_id"] = id
+	return e
+}
+
+// translate replaces the event message with its translation
+func (l *Logger) translate(event *Event) {
+	if l.translator == nil {
+		return
+	}
+	id, ok := event.metadata["msg_id"].(string)
+	if !ok || id == "" {
+		return
+	}
+	if message := l.translator(id, event.metadata); message != "" {
+		event.message = message
+	}
+}
+
+// NewTemplateTranslator returns a translator rendering the text/template of
+// the catalog matching the message id, with the event metadata as data
+// (e.g. "scan of {{.target}} started").
+func NewTemplateTranslator(catalog map[string]string) (Translator, error) {
+	templates := make(map[string]*template.Template, len(catalog))
+	for id, text := range catalog {
+		tmpl, err := template.New(id).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse message %q: %w", id, err)
+		}
+		templates[id] = tmpl
+	}
+	return func(msgID string, args map[string]interface{}) string {
+		tmpl, ok := templates[msgID]
+		if !ok {
+			return ""
+		}
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, args); err != nil {
+			return ""
+		}
+		return buffer.String()
+	}, nil
+}
@@ -0,0 +1,29 @@
+package gologger
+
+import "github.com/projectdiscovery/gologger/levels"
+
+// SetVerbosity maps a verbosity count (e.g. 2 for -vv) onto the logger:
+// a positive count enables the verbose level and only the verbose events
+// with a verbosity up to the count are written. A count of 0 restores the
+// default where all verbose events follow the max level.
+func (l *Logger) SetVerbosity(count int) {
+	l.verbosity.Store(int64(count))
+	if count > 0 && l.loadMaxLevel().Rank() < levels.LevelVerbose.Rank() {
+		l.SetMaxLevel(levels.LevelVerbose)
+	}
+}
+
+// VerbosityN prints a string only when the verbosity count of the logger
+// is at least n, allowing increasingly detailed output for -v, -vv and -vvv.
+// VerbosityN(1) is equivalent to Verbose.
+func (l *Logger) VerbosityN(n int) *Event {
+	event := l.Verbose()
+	event.verbosity = n
+	return event
+}
+
+// VerbosityN prints a string only when the verbosity count of the default
+// logger is at least n
+func VerbosityN(n int) *Event {
+	return DefaultLogger.VerbosityN(n)
+}
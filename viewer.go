@@ -0,0 +1,97 @@
+package gologger
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+//go:embed viewer.html
+var viewerPage []byte
+
+// viewerBuffer is the number of events queued for each browser
+const viewerBuffer = 256
+
+// ViewerHandler returns an http handler serving a minimal live log view,
+// so that operators of server-mode tools can watch the logs in a browser
+// without external infrastructure. The page at the handler root streams the
+// events published by follow, starting with its backlog, through server-sent
+// events and lets the displayed levels be chosen.
+//
+// The events are streamed from the events path below the handler root,
+// which accepts a levels query parameter (e.g. ?levels=error,warning).
+// The handler is usually mounted with http.StripPrefix.
+func ViewerHandler(follow *writer.Follow) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/events") || r.URL.Path == "events" {
+			serveViewerEvents(w, r, follow)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(viewerPage)
+	})
+}
+
+// serveViewerEvents streams the events as server-sent events until the
+// client disconnects or the follow writer is closed
+func serveViewerEvents(w http.ResponseWriter, r *http.Request, follow *writer.Follow) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	var allowed map[levels.Level]bool
+	if names := r.URL.Query().Get("levels"); names != "" {
+		allowed = make(map[levels.Level]bool)
+		for _, name := range strings.Split(names, ",") {
+			level, err := levels.Parse(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			allowed[level] = true
+		}
+	}
+
+	lines, unsubscribe := follow.Subscribe(viewerBuffer)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			if allowed != nil && !allowed[line.Level] {
+				continue
+			}
+			// the encoder ends the data with a new line, completing the message
+			_, _ = w.Write([]byte("data: "))
+			if err := encoder.Encode(struct {
+				Level levels.Level `json:"level"`
+				Data  string       `json:"data"`
+			}{line.Level, string(line.Data)}); err != nil {
+				return
+			}
+			_, _ = w.Write([]byte("\n"))
+			flusher.Flush()
+		}
+	}
+}
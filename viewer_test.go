@@ -0,0 +1,85 @@
+package gologger
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/levels"
+	"github.com/projectdiscovery/gologger/writer"
+)
+
+func TestViewerHandler(t *testing.T) {
+	follow := writer.NewFollow(nil, 10)
+	server := httptest.NewServer(http.StripPrefix("/logs", ViewerHandler(follow)))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/logs/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(page), "EventSource") {
+		t.Errorf("page = %d %q", response.StatusCode, page)
+	}
+
+	response, err = http.Get(server.URL + "/logs/events?levels=loud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid levels = %d", response.StatusCode)
+	}
+
+	response, err = http.Post(server.URL+"/logs/", "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d", response.StatusCode)
+	}
+}
+
+func TestViewerEvents(t *testing.T) {
+	follow := writer.NewFollow(nil, 10)
+	server := httptest.NewServer(ViewerHandler(follow))
+	defer server.Close()
+
+	follow.Write([]byte(`{"msg":"backlog"}`), levels.LevelError)
+	follow.Write([]byte(`{"msg":"filtered"}`), levels.LevelInfo)
+
+	response, err := http.Get(server.URL + "/events?levels=error,warning")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("content type = %q", contentType)
+	}
+
+	follow.Write([]byte(`{"msg":"debug"}`), levels.LevelDebug)
+	follow.Write([]byte(`{"msg":"live"}`), levels.LevelWarning)
+	// closing the follow writer ends the stream
+	follow.Close()
+
+	var messages []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			messages = append(messages, data)
+		}
+	}
+	want := []string{
+		`{"level":"error","data":"{\"msg\":\"backlog\"}"}`,
+		`{"level":"warning","data":"{\"msg\":\"live\"}"}`,
+	}
+	if strings.Join(messages, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", messages, want)
+	}
+}
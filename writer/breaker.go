@@ -0,0 +1,61 @@
+package writer
+
+import (
+	"sync"
+	"time"
+)
+
+// breaker is a simple circuit breaker which opens after a number of
+// consecutive failures and stays open for the cooldown duration.
+type breaker struct {
+	mutex     sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+// newBreaker returns a new breaker or nil if threshold is not positive
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether an operation can be attempted
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return time.Now().After(b.openUntil)
+}
+
+// success resets the consecutive failures counter
+func (b *breaker) success() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures = 0
+}
+
+// failure records a failed operation and opens the breaker once the threshold is reached
+func (b *breaker) failure() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		b.failures = 0
+	}
+}
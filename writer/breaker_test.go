@@ -0,0 +1,90 @@
+package writer
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestBreaker(t *testing.T) {
+	disabled := newBreaker(0, time.Hour)
+	disabled.failure()
+	if !disabled.allow() {
+		t.Error("disabled breaker opened")
+	}
+
+	b := newBreaker(2, 50*time.Millisecond)
+	b.failure()
+	b.success()
+	b.failure()
+	if !b.allow() {
+		t.Error("breaker opened although a success reset the failures")
+	}
+	b.failure()
+	if b.allow() {
+		t.Error("breaker still closed after 2 consecutive failures")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if !b.allow() {
+		t.Error("breaker still open after the cooldown")
+	}
+}
+
+func TestWriteFailuresOpenBreaker(t *testing.T) {
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:         t.TempDir(),
+		FileName:         "app.log",
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// writes to a closed file fail
+	w.logFile.Close()
+
+	for i := 0; i < 3; i++ {
+		w.Write([]byte("line"), levels.LevelInfo)
+	}
+	if dropped := w.Dropped(); dropped != 3 {
+		t.Errorf("dropped %d events, want 3", dropped)
+	}
+	if w.breaker.allow() {
+		t.Error("breaker not opened by the failed writes")
+	}
+}
+
+func TestWriteTimeout(t *testing.T) {
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:     t.TempDir(),
+		FileName:     "app.log",
+		WriteTimeout: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// a pipe without reader blocks once its buffer is full, like a hung mount
+	reader, hung, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.logFile.Close()
+	w.logFile = hung
+
+	start := time.Now()
+	w.Write(bytes.Repeat([]byte("x"), 1<<20), levels.LevelInfo)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("write blocked for %s", elapsed)
+	}
+	// the hanging write makes the next ones fail fast
+	w.Write([]byte("line"), levels.LevelInfo)
+	if dropped := w.Dropped(); dropped != 2 {
+		t.Errorf("dropped %d events, want 2", dropped)
+	}
+	reader.Close()
+}
@@ -1,6 +1,7 @@
 package writer
 
 import (
+	"bytes"
 	"os"
 	"sync"
 
@@ -10,9 +11,19 @@ import (
 // CLI is a concurrent output writer to terminal.
 type CLI struct {
 	mutex *sync.Mutex
+	// transient is set while a transient event is displayed
+	transient bool
 }
 
-var _ Writer = &CLI{}
+var (
+	_ Writer       = &CLI{}
+	_ Capabilities = &CLI{}
+	_ Streamer     = &CLI{}
+	_ Transient    = &CLI{}
+)
+
+// eraseLine moves the cursor to the start of the line and clears it
+const eraseLine = "\r\x1b[K"
 
 // NewCLI returns a new CLI concurrent log writer.
 func NewCLI() *CLI {
@@ -24,12 +35,62 @@ func (w *CLI) Write(data []byte, level levels.Level) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
-	switch level {
-	case levels.LevelSilent:
-		os.Stdout.Write(data)
-		os.Stdout.WriteString(NewLine)
-	default:
-		os.Stderr.Write(data)
-		os.Stderr.WriteString(NewLine)
+	w.erase()
+	stream := w.Stream(level)
+	stream.Write(data)
+	stream.WriteString(NewLine)
+}
+
+// WriteTransient displays the data on stderr until the next write, only the
+// first line being shown
+func (w *CLI) WriteTransient(data []byte, level levels.Level) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		data = data[:i]
+	}
+	os.Stderr.WriteString(eraseLine)
+	os.Stderr.Write(data)
+	w.transient = true
+}
+
+// Close erases the transient event being displayed, if any
+func (w *CLI) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.erase()
+}
+
+// erase clears the transient event being displayed
+func (w *CLI) erase() {
+	if w.transient {
+		os.Stderr.WriteString(eraseLine)
+		w.transient = false
+	}
+}
+
+// Stream returns stdout for silent events, which are data meant to be piped
+// to other tools, and stderr for the diagnostics of the other levels
+func (w *CLI) Stream(level levels.Level) *os.File {
+	if level == levels.LevelSilent {
+		return os.Stdout
 	}
+	return os.Stderr
+}
+
+// SupportsColor reports whether colored output can be rendered on stderr
+func (w *CLI) SupportsColor() bool {
+	return SupportsColor(os.Stderr)
+}
+
+// IsTerminal reports whether the output is attached to a terminal
+func (w *CLI) IsTerminal() bool {
+	return IsTerminal(os.Stderr)
+}
+
+// PrefersJSON returns false, terminal output is meant for humans
+func (w *CLI) PrefersJSON() bool {
+	return false
 }
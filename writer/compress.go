@@ -0,0 +1,109 @@
+package writer
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compressor compresses the rotated log files
+type Compressor interface {
+	// Extension returns the extension appended to the compressed files,
+	// an empty extension keeping the rotated files uncompressed
+	Extension() string
+	// Compress streams the compressed content of src to dst
+	Compress(dst io.Writer, src io.Reader) error
+}
+
+// Available compressors
+var (
+	// GzipCompressor compresses rotated files with gzip (.gz)
+	GzipCompressor Compressor = gzipCompressor{}
+	// ZstdCompressor compresses rotated files with zstd (.zst)
+	ZstdCompressor Compressor = zstdCompressor{}
+	// NoCompressor keeps the rotated files uncompressed
+	NoCompressor Compressor = noCompressor{}
+)
+
+type gzipCompressor struct{}
+
+func (gzipCompressor) Extension() string { return "gz" }
+
+func (gzipCompressor) Compress(dst io.Writer, src io.Reader) error {
+	gz := gzip.NewWriter(dst)
+	if f, ok := src.(*os.File); ok {
+		gz.Name = filepath.Base(f.Name())
+	}
+	if _, err := io.Copy(gz, src); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+type zstdCompressor struct{}
+
+func (zstdCompressor) Extension() string { return "zst" }
+
+func (zstdCompressor) Compress(dst io.Writer, src io.Reader) error {
+	enc, err := zstd.NewWriter(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(enc, src); err != nil {
+		_ = enc.Close()
+		return err
+	}
+	return enc.Close()
+}
+
+type noCompressor struct{}
+
+func (noCompressor) Extension() string { return "" }
+
+func (noCompressor) Compress(dst io.Writer, src io.Reader) error {
+	_, err := io.Copy(dst, src)
+	return err
+}
+
+// compressorFor returns the compressor handling the archive format
+func compressorFor(format string) (Compressor, error) {
+	switch format {
+	case "", "gz", "gzip":
+		return GzipCompressor, nil
+	case "zst", "zstd":
+		return ZstdCompressor, nil
+	case "none":
+		return NoCompressor, nil
+	default:
+		return nil, fmt.Errorf("unsupported archive format: %s", format)
+	}
+}
+
+// compressFile compresses the source file into destination, removing the
+// partially written destination on failure
+func compressFile(compressor Compressor, source, destination string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	if err := compressor.Compress(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(destination)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(destination)
+		return err
+	}
+	return nil
+}
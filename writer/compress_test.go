@@ -0,0 +1,117 @@
+package writer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestCompressRotatedFiles(t *testing.T) {
+	tests := []struct {
+		format string
+		ext    string
+		read   func(r io.Reader) ([]byte, error)
+	}{
+		{"gz", ".gz", func(r io.Reader) ([]byte, error) {
+			zr, err := gzip.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return io.ReadAll(zr)
+		}},
+		{"zst", ".zst", func(r io.Reader) ([]byte, error) {
+			zr, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			defer zr.Close()
+			return io.ReadAll(zr)
+		}},
+		{"none", "", io.ReadAll},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			dir := t.TempDir()
+			errs := make(chan error, 1)
+			w, err := NewFileWithRotation(&FileWithRotationOptions{
+				Location:         dir,
+				FileName:         "app.log",
+				BackupTimeFormat: "2006-01-02T15-04-05",
+				Compress:         true,
+				ArchiveFormat:    test.format,
+				OnCompressError:  func(filename string, err error) { errs <- err },
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+			w.Write([]byte("rotated line"), levels.LevelInfo)
+			if err := w.Rotate(); err != nil {
+				t.Fatal(err)
+			}
+
+			// compression runs in the background, the rotated file being
+			// removed once compressed
+			var archive string
+			for deadline := time.Now().Add(5 * time.Second); archive == "" && time.Now().Before(deadline); {
+				select {
+				case err := <-errs:
+					t.Fatal(err)
+				default:
+				}
+				archives, _ := filepath.Glob(filepath.Join(dir, "app.*.log"+test.ext))
+				rotated, _ := filepath.Glob(filepath.Join(dir, "app.*.log"))
+				if len(archives) == 1 && (test.ext == "" || len(rotated) == 0) {
+					archive = archives[0]
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			if archive == "" {
+				t.Fatal("rotated file not found")
+			}
+			f, err := os.Open(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			data, err := test.read(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, []byte("rotated line\n")) {
+				t.Errorf("archive content = %q", data)
+			}
+		})
+	}
+}
+
+func TestCompressorFor(t *testing.T) {
+	tests := []struct {
+		format string
+		ext    string
+		err    bool
+	}{
+		{"", "gz", false},
+		{"gzip", "gz", false},
+		{"zstd", "zst", false},
+		{"none", "", false},
+		{"rar", "", true},
+	}
+	for _, test := range tests {
+		compressor, err := compressorFor(test.format)
+		if (err != nil) != test.err {
+			t.Errorf("compressorFor(%q) error = %v", test.format, err)
+			continue
+		}
+		if err == nil && compressor.Extension() != test.ext {
+			t.Errorf("compressorFor(%q) extension = %q, want %q", test.format, compressor.Extension(), test.ext)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+//go:build linux || darwin
+
+package writer
+
+import (
+	"math"
+	"syscall"
+)
+
+// availableSpace returns the bytes which can still be written to a file
+// of the given size in dir: the space available to unprivileged users,
+// bounded by the file size limit (ulimit -f) of the process
+func availableSpace(dir string, fileSize int64) (uint64, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, false
+	}
+	available := uint64(stat.Bavail) * uint64(stat.Bsize)
+
+	var limit syscall.Rlimit
+	// infinite limits are reported as the max int64 or uint64 value
+	if err := syscall.Getrlimit(syscall.RLIMIT_FSIZE, &limit); err == nil && uint64(limit.Cur) < math.MaxInt64 {
+		remaining := uint64(0)
+		if uint64(fileSize) < limit.Cur {
+			remaining = limit.Cur - uint64(fileSize)
+		}
+		if remaining < available {
+			available = remaining
+		}
+	}
+	return available, true
+}
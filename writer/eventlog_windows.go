@@ -0,0 +1,119 @@
+//go:build windows
+
+package writer
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+var (
+	advapi32              = syscall.NewLazyDLL("advapi32.dll")
+	registerEventSource   = advapi32.NewProc("RegisterEventSourceW")
+	reportEvent           = advapi32.NewProc("ReportEventW")
+	deregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+)
+
+// Event log entry types
+const (
+	eventLogError       = 0x0001
+	eventLogWarning     = 0x0002
+	eventLogInformation = 0x0004
+)
+
+// EventLog is a concurrent output writer reporting events to the Windows
+// event log under an event source.
+type EventLog struct {
+	mutex   *sync.Mutex
+	handle  uintptr
+	dropped atomic.Uint64
+}
+
+var (
+	_ Writer       = &EventLog{}
+	_ Capabilities = &EventLog{}
+)
+
+// NewEventLog returns a new event log writer reporting the events under the
+// source, usually the program name
+func NewEventLog(source string) (*EventLog, error) {
+	name, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return nil, err
+	}
+	handle, _, err := registerEventSource.Call(0, uintptr(unsafe.Pointer(name)))
+	if handle == 0 {
+		return nil, err
+	}
+	return &EventLog{mutex: &sync.Mutex{}, handle: handle}, nil
+}
+
+// Write reports the data as an event log entry
+func (w *EventLog) Write(data []byte, level levels.Level) {
+	message, err := syscall.UTF16PtrFromString(strings.ReplaceAll(string(data), "\x00", ""))
+	if err != nil {
+		w.dropped.Add(1)
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.handle == 0 {
+		w.dropped.Add(1)
+		return
+	}
+	strs := []*uint16{message}
+	r, _, _ := reportEvent.Call(w.handle, uintptr(eventLogType(level)), 0, 1, 0, 1, 0, uintptr(unsafe.Pointer(&strs[0])), 0)
+	if r == 0 {
+		w.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events that could not be reported
+func (w *EventLog) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close deregisters the event source
+func (w *EventLog) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.handle != 0 {
+		_, _, _ = deregisterEventSource.Call(w.handle)
+		w.handle = 0
+	}
+}
+
+// eventLogType returns the entry type of the level
+func eventLogType(level levels.Level) uint16 {
+	switch level {
+	case levels.LevelFatal, levels.LevelError:
+		return eventLogError
+	case levels.LevelWarning:
+		return eventLogWarning
+	default:
+		return eventLogInformation
+	}
+}
+
+// SupportsColor returns false as event log entries are plain text
+func (w *EventLog) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the output is the event log
+func (w *EventLog) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns false, the entries are read in the event viewer
+func (w *EventLog) PrefersJSON() bool {
+	return false
+}
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/mholt/archiver/v3"
 	"github.com/projectdiscovery/gologger/levels"
 	"gopkg.in/djherbis/times.v1"
 )
@@ -36,34 +38,101 @@ type FileWithRotation struct {
 	mutex       *sync.Mutex
 	logFile     *os.File
 	logfileTime time.Time
+	// fileName is the current file name, which changes over time when
+	// the FileName option is a time pattern
+	fileName      string
+	nextNameCheck time.Time
+	breaker       *breaker
+	inflight      atomic.Bool
+	dropped       atomic.Uint64
+	// lowSpace is set while the disk space is below MinFreeSpace
+	lowSpace       bool
+	lowSpaceWarned bool
+	nextSpaceCheck time.Time
+	// archiveMutex serializes the compression and the pruning of the
+	// rotated files, which run outside of mutex
+	archiveMutex *sync.Mutex
+	// timePattern matches the names of the files of the time pattern
+	timePattern *regexp.Regexp
+	// done stops the rotation scheduler once the writer is closed
+	done   chan struct{}
+	closed bool
 }
 
+// diskSpaceCheckInterval is the interval between the disk space checks
+const diskSpaceCheckInterval = 10 * time.Second
+
 type FileWithRotationOptions struct {
 	Location         string
 	Rotate           bool
 	rotationcheck    time.Duration
 	RotationInterval time.Duration
+	// FileName is the name of the log file. It can be a time pattern such as
+	// "scan-%Y-%m-%d.log" (%Y, %y, %m, %d, %H, %M and %% are supported), a
+	// new file being opened each time the expanded name changes.
 	FileName         string
 	Compress         bool
 	MaxSize          int
 	BackupTimeFormat string
-	ArchiveFormat    string
+	// ArchiveFormat selects the compressor when Compressor is not set
+	// ("gz", "zst" or "none")
+	ArchiveFormat string
+	// Compressor compresses the rotated files when Compress is enabled
+	Compressor Compressor
+	// OnCompressError is called when a rotated file cannot be compressed,
+	// the uncompressed file being kept
+	OnCompressError func(filename string, err error)
 	// Helpers
 	RotateEachHour bool
 	RotateEachDay  bool
+	// WriteTimeout is the maximum duration a write may block before being
+	// abandoned, useful for hung network filesystems (0 disables it)
+	WriteTimeout time.Duration
+	// BreakerThreshold is the number of consecutive failed writes after
+	// which writes are dropped for BreakerCooldown (0 disables it)
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// SyncOnError fsyncs the file after each Error/Fatal event
+	SyncOnError bool
+	// SyncWrites opens the file with O_SYNC so every write is durable
+	SyncWrites bool
+	// MaxBackups is the maximum number of rotated files to keep (0 keeps all)
+	MaxBackups int
+	// MaxAge is the maximum age of the rotated files to keep (0 keeps all)
+	MaxAge time.Duration
+	// MaxTotalSize is the maximum total size in megabytes of the rotated
+	// files to keep, the oldest ones being removed first (0 keeps all)
+	MaxTotalSize int
+	// MinFreeSpace is the available disk space in megabytes below which
+	// Debug, Verbose and Trace events are dropped, preventing small
+	// container filesystems from filling up. The file size limit of the
+	// process (ulimit -f) is also considered (0 disables it).
+	MinFreeSpace int
+	// OnLowDiskSpace is called once when the available space first drops
+	// below MinFreeSpace with the available space in bytes, the dropped
+	// events being counted by Dropped
+	OnLowDiskSpace func(available uint64)
 }
 
+// ErrWriteTimeout is returned when a write exceeds the configured timeout
+var ErrWriteTimeout = errors.New("write timeout exceeded")
+
+// ErrClosed is returned when the writer is used after being closed
+var ErrClosed = errors.New("writer closed")
+
 var DefaultFileWithRotationOptions FileWithRotationOptions
 
 // NewFileWithRotation returns a new file concurrent log writer.
 func NewFileWithRotation(options *FileWithRotationOptions) (*FileWithRotation, error) {
 	fwr := &FileWithRotation{
-		options: options,
-		mutex:   &sync.Mutex{},
+		options:      options,
+		mutex:        &sync.Mutex{},
+		archiveMutex: &sync.Mutex{},
+		breaker:      newBreaker(options.BreakerThreshold, options.BreakerCooldown),
+		done:         make(chan struct{}),
 	}
-	// set log rotator monitor
-	if fwr.options.Rotate {
-		go scheduler(time.NewTicker(options.rotationcheck), fwr.checkAndRotate)
+	if strings.Contains(options.FileName, "%") {
+		fwr.timePattern = timePatternRegexp(options.FileName)
 	}
 
 	err := os.MkdirAll(fwr.options.Location, 0755)
@@ -76,39 +145,127 @@ func NewFileWithRotation(options *FileWithRotationOptions) (*FileWithRotation, e
 		return nil, err
 	}
 
+	// set log rotator monitor
+	if fwr.options.Rotate {
+		go scheduler(time.NewTicker(options.rotationcheck), fwr.done, fwr.checkAndRotate)
+	}
 	return fwr, nil
 }
 
-// Write writes an output to the underlying file
+// Write writes an output to the underlying file. Writes after Close are
+// dropped and counted by Dropped.
 func (w *FileWithRotation) Write(data []byte, level levels.Level) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
-	switch level {
-	case levels.LevelSilent:
-		_, err := w.logFile.Write(data)
-		if err != nil {
-			return
-		}
+	if w.closed {
+		w.dropped.Add(1)
+		return
+	}
+	w.switchTimePatternFile()
+	if level.IsDiagnostic() && w.lowDiskSpace() {
+		w.dropped.Add(1)
+		return
+	}
+	if !w.breaker.allow() {
+		w.dropped.Add(1)
+		return
+	}
+	durable := w.options.SyncOnError && (level == levels.LevelError || level == levels.LevelFatal)
+	if err := w.writeWithTimeout(data, durable); err != nil {
+		w.breaker.failure()
+		w.dropped.Add(1)
+		return
+	}
+	w.breaker.success()
+}
 
-		_, err = w.logFile.Write([]byte("\n"))
-		if err != nil {
-			return
-		}
+// lowDiskSpace reports whether the available disk space is below the
+// MinFreeSpace option, checking it at most every diskSpaceCheckInterval
+func (w *FileWithRotation) lowDiskSpace() bool {
+	if w.options.MinFreeSpace <= 0 {
+		return false
+	}
+	now := time.Now()
+	if now.Before(w.nextSpaceCheck) {
+		return w.lowSpace
+	}
+	w.nextSpaceCheck = now.Add(diskSpaceCheckInterval)
 
-	default:
-		_, err := w.logFile.Write(data)
-		if err != nil {
-			return
-		}
-		_, err = w.logFile.Write([]byte("\n"))
-		if err != nil {
-			return
+	var size int64
+	if info, err := w.logFile.Stat(); err == nil {
+		size = info.Size()
+	}
+	available, ok := availableSpace(w.options.Location, size)
+	if !ok {
+		return w.lowSpace
+	}
+	w.lowSpace = available < uint64(w.options.MinFreeSpace)*1024*1024
+	if w.lowSpace && !w.lowSpaceWarned {
+		w.lowSpaceWarned = true
+		if w.options.OnLowDiskSpace != nil {
+			w.options.OnLowDiskSpace(available)
 		}
 	}
+	return w.lowSpace
+}
+
+// Dropped returns the number of events dropped due to failed, timed out,
+// short-circuited writes, low disk space or the writer being closed
+func (w *FileWithRotation) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// writeWithTimeout writes the line giving up after the configured timeout.
+// A write that is still hanging causes subsequent writes to fail fast.
+func (w *FileWithRotation) writeWithTimeout(data []byte, durable bool) error {
+	if w.options.WriteTimeout <= 0 {
+		return w.writeLine(w.logFile, data, durable)
+	}
+	if !w.inflight.CompareAndSwap(false, true) {
+		return ErrWriteTimeout
+	}
+
+	// the write may outlive the call, so it works on a copy of the data
+	data = append([]byte(nil), data...)
+	done := make(chan error, 1)
+	go func(logFile *os.File) {
+		done <- w.writeLine(logFile, data, durable)
+		w.inflight.Store(false)
+	}(w.logFile)
+
+	timer := time.NewTimer(w.options.WriteTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return ErrWriteTimeout
+	}
+}
+
+func (w *FileWithRotation) writeLine(logFile *os.File, data []byte, durable bool) error {
+	if _, err := logFile.Write(data); err != nil {
+		return err
+	}
+	if _, err := logFile.Write([]byte("\n")); err != nil {
+		return err
+	}
+	if durable {
+		return logFile.Sync()
+	}
+	return nil
 }
 
 func (w *FileWithRotation) checkAndRotate() {
+	// the file and its time are swapped by Rotate and the time patterns
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
 	timeNow := time.Now()
 	// check size
 	currentFileSizeMb, err := w.logFile.Stat()
@@ -127,20 +284,56 @@ func (w *FileWithRotation) checkAndRotate() {
 	// - RotateEachHour set and condition met
 	// - RotateEachDay set and condition met
 	if filesizeCheck || filechangedateCheck || rotateEachHourCheck || rotateEachDayCheck {
-		w.mutex.Lock()
-		w.Close()
+		w.closeFile()
 		w.renameAndCompressLogs()
 		_ = w.newLogger()
-		w.mutex.Unlock()
 	}
 }
 
-// Close and flushes the logger
+// Rotate forces the rotation of the current log file
+func (w *FileWithRotation) Rotate() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+	w.closeFile()
+	w.renameAndCompressLogs()
+	return w.newLogger()
+}
+
+// Close flushes and closes the log file and stops the rotation. It can be
+// called several times.
 func (w *FileWithRotation) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.done)
+	w.closeFile()
+}
+
+// closeFile flushes and closes the current log file
+func (w *FileWithRotation) closeFile() {
 	_ = w.logFile.Sync()
 	w.logFile.Close()
 }
 
+// Flush commits the written data to disk
+func (w *FileWithRotation) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+	return w.logFile.Sync()
+}
+
 func (w *FileWithRotation) newLoggerSync() (err error) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
@@ -149,7 +342,8 @@ func (w *FileWithRotation) newLoggerSync() (err error) {
 }
 
 func (w *FileWithRotation) newLogger() (err error) {
-	filename := filepath.Join(w.options.Location, w.options.FileName)
+	w.fileName = expandTimePattern(w.options.FileName, time.Now())
+	filename := filepath.Join(w.options.Location, w.fileName)
 	logFile, err := w.CreateFile(filename)
 	if err != nil {
 		return err
@@ -165,7 +359,11 @@ func (w *FileWithRotation) newLogger() (err error) {
 }
 
 func (w *FileWithRotation) CreateFile(filename string) (*os.File, error) {
-	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0755)
+	flags := os.O_APPEND | os.O_CREATE | os.O_RDWR
+	if w.options.SyncWrites {
+		flags |= os.O_SYNC
+	}
+	f, err := os.OpenFile(filename, flags, 0755)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +372,7 @@ func (w *FileWithRotation) CreateFile(filename string) (*os.File, error) {
 
 func (w *FileWithRotation) renameAndCompressLogs() {
 	// snapshot current filename log
-	filename := filepath.Join(w.options.Location, w.options.FileName)
+	filename := filepath.Join(w.options.Location, w.fileName)
 	fileExt := filepath.Ext(filename)
 	filenameBase := strings.TrimSuffix(filename, fileExt)
 	timeToSave := time.Now()
@@ -184,23 +382,158 @@ func (w *FileWithRotation) renameAndCompressLogs() {
 		timeToSave = timeToSave.Truncate(24 * time.Hour)
 	}
 	tmpFilename := filenameBase + "." + timeToSave.Format(w.options.BackupTimeFormat) + fileExt
+	current := w.fileName
 	_ = os.Rename(filename, tmpFilename)
 
 	if w.options.Compress {
 		// start asyncronous compressing
 		go func(filename string) {
-			err := archiver.CompressFile(tmpFilename, filename+"."+w.options.ArchiveFormat)
-			if err == nil {
-				// remove the original file
-				os.RemoveAll(tmpFilename)
+			w.archiveMutex.Lock()
+			err := w.compress(filename)
+			w.archiveMutex.Unlock()
+			if err != nil && w.options.OnCompressError != nil {
+				w.options.OnCompressError(filename, err)
 			}
+			w.pruneBackups(current)
 		}(tmpFilename)
+		return
+	}
+	w.pruneBackups(current)
+}
+
+// compress compresses the rotated file and removes it on success
+func (w *FileWithRotation) compress(filename string) error {
+	compressor := w.options.Compressor
+	if compressor == nil {
+		var err error
+		if compressor, err = compressorFor(w.options.ArchiveFormat); err != nil {
+			return err
+		}
+	}
+	ext := compressor.Extension()
+	if ext == "" {
+		return nil
+	}
+	if err := compressFile(compressor, filename, filename+"."+ext); err != nil {
+		return err
+	}
+	return os.Remove(filename)
+}
+
+// switchTimePatternFile opens a new file when the time pattern of the file
+// name expands to a new name, checking at most once per second
+func (w *FileWithRotation) switchTimePatternFile() {
+	if !strings.Contains(w.options.FileName, "%") {
+		return
+	}
+	now := time.Now()
+	if now.Before(w.nextNameCheck) {
+		return
+	}
+	w.nextNameCheck = now.Truncate(time.Second).Add(time.Second)
+	if expandTimePattern(w.options.FileName, now) == w.fileName {
+		return
+	}
+	w.closeFile()
+	if err := w.newLogger(); err != nil {
+		return
+	}
+	w.pruneBackups(w.fileName)
+}
+
+// pruneBackups removes the rotated files exceeding the retention options,
+// except the current file. With time patterns the files of the previous
+// periods are considered as backups.
+func (w *FileWithRotation) pruneBackups(current string) {
+	if w.options.MaxBackups <= 0 && w.options.MaxAge <= 0 && w.options.MaxTotalSize <= 0 {
+		return
+	}
+	w.archiveMutex.Lock()
+	defer w.archiveMutex.Unlock()
+
+	entries, err := os.ReadDir(w.options.Location)
+	if err != nil {
+		return
+	}
+	var backups []os.FileInfo
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == current || !w.isBackup(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, info)
+	}
+	// newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().After(backups[j].ModTime())
+	})
+
+	var totalSize int64
+	maxTotalSize := int64(w.options.MaxTotalSize) * 1024 * 1024
+	for i, backup := range backups {
+		totalSize += backup.Size()
+		expired := w.options.MaxAge > 0 && time.Since(backup.ModTime()) > w.options.MaxAge
+		tooMany := w.options.MaxBackups > 0 && i >= w.options.MaxBackups
+		tooLarge := maxTotalSize > 0 && totalSize > maxTotalSize
+		if expired || tooMany || tooLarge {
+			_ = os.Remove(filepath.Join(w.options.Location, backup.Name()))
+		}
+	}
+}
+
+// isBackup reports whether name is a rotated file, named after the file
+// name with the BackupTimeFormat time before the extension, e.g.
+// app.2006-01-02T15-04-05.log, optionally compressed
+func (w *FileWithRotation) isBackup(name string) bool {
+	for _, ext := range w.archiveExtensions() {
+		if strings.HasSuffix(name, "."+ext) {
+			name = strings.TrimSuffix(name, "."+ext)
+			break
+		}
+	}
+	if w.timePattern != nil {
+		match := w.timePattern.FindStringSubmatch(name)
+		if match == nil {
+			return false
+		}
+		if match[1] == "" {
+			return true
+		}
+		_, err := time.Parse(w.options.BackupTimeFormat, match[1])
+		return err == nil
+	}
+
+	fileExt := filepath.Ext(w.options.FileName)
+	base := strings.TrimSuffix(w.options.FileName, fileExt) + "."
+	if len(name) < len(base)+len(fileExt) || !strings.HasPrefix(name, base) || !strings.HasSuffix(name, fileExt) {
+		return false
 	}
+	_, err := time.Parse(w.options.BackupTimeFormat, name[len(base):len(name)-len(fileExt)])
+	return err == nil
 }
 
-func scheduler(tick *time.Ticker, f func()) {
-	for range tick.C {
-		f()
+// archiveExtensions returns the extensions of the compressed rotated files
+func (w *FileWithRotation) archiveExtensions() []string {
+	extensions := []string{GzipCompressor.Extension(), ZstdCompressor.Extension()}
+	if w.options.Compressor != nil && w.options.Compressor.Extension() != "" {
+		extensions = append(extensions, w.options.Compressor.Extension())
+	}
+	return extensions
+}
+
+// scheduler calls f on each tick until done is closed
+func scheduler(tick *time.Ticker, done <-chan struct{}, f func()) {
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			f()
+		case <-done:
+			return
+		}
 	}
 }
 
@@ -217,3 +550,89 @@ func getChangeTime(filename string) (time.Time, error) {
 
 	return timeNow, errors.New("No change time")
 }
+
+// SupportsColor returns false, escape sequences would end up in the log files
+func (w *FileWithRotation) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the output is a file
+func (w *FileWithRotation) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns false, files get the configured formatting
+func (w *FileWithRotation) PrefersJSON() bool {
+	return false
+}
+
+// timePatternRegexp returns an anchored regular expression matching the
+// names the time pattern expands to, optionally followed by a rotation time
+// before the extension, captured by the first group
+func timePatternRegexp(pattern string) *regexp.Regexp {
+	ext := filepath.Ext(pattern)
+	if strings.Contains(ext, "%") {
+		ext = ""
+	}
+	base := strings.TrimSuffix(pattern, ext)
+
+	var builder strings.Builder
+	builder.WriteByte('^')
+	for i := 0; i < len(base); i++ {
+		if base[i] != '%' || i+1 == len(base) {
+			builder.WriteString(regexp.QuoteMeta(base[i : i+1]))
+			continue
+		}
+		i++
+		switch base[i] {
+		case 'Y':
+			builder.WriteString(`\d{4}`)
+		case 'y', 'm', 'd', 'H', 'M':
+			builder.WriteString(`\d{2}`)
+		case '%':
+			builder.WriteByte('%')
+		default:
+			builder.WriteString(regexp.QuoteMeta(base[i-1 : i+1]))
+		}
+	}
+	builder.WriteString(`(?:\.(.+))?`)
+	builder.WriteString(regexp.QuoteMeta(ext))
+	builder.WriteByte('$')
+	return regexp.MustCompile(builder.String())
+}
+
+// expandTimePattern replaces the strftime-like directives of the pattern
+// with the values of t
+func expandTimePattern(pattern string, t time.Time) string {
+	if !strings.Contains(pattern, "%") {
+		return pattern
+	}
+	var builder strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' || i+1 == len(pattern) {
+			builder.WriteByte(pattern[i])
+			continue
+		}
+		i++
+		switch pattern[i] {
+		case 'Y':
+			builder.WriteString(t.Format("2006"))
+		case 'y':
+			builder.WriteString(t.Format("06"))
+		case 'm':
+			builder.WriteString(t.Format("01"))
+		case 'd':
+			builder.WriteString(t.Format("02"))
+		case 'H':
+			builder.WriteString(t.Format("15"))
+		case 'M':
+			builder.WriteString(t.Format("04"))
+		case '%':
+			builder.WriteByte('%')
+		default:
+			builder.WriteByte('%')
+			builder.WriteByte(pattern[i])
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,315 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestIsBackup(t *testing.T) {
+	w := &FileWithRotation{options: &FileWithRotationOptions{
+		FileName:         "app.log",
+		BackupTimeFormat: "2006-01-02T15-04-05",
+	}}
+
+	tests := []struct {
+		name   string
+		backup bool
+	}{
+		{"app.2024-01-02T03-04-05.log", true},
+		{"app.2024-01-02T03-04-05.log.gz", true},
+		{"app.2024-01-02T03-04-05.log.zst", true},
+		{"app.log", false},
+		{"app.yaml", false},
+		{"app.notes.log", false},
+		{"app.2024-01-02T03-04-05.txt", false},
+		{"app.2024-01-02T03-04-05.log.bak", false},
+		{"application.2024-01-02T03-04-05.log", false},
+	}
+	for _, test := range tests {
+		if got := w.isBackup(test.name); got != test.backup {
+			t.Errorf("isBackup(%q) = %v, want %v", test.name, got, test.backup)
+		}
+	}
+}
+
+func TestIsBackupTimePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		backup  bool
+	}{
+		{"scan-%Y-%m-%d.log", "scan-2024-01-02.log", true},
+		{"scan-%Y-%m-%d.log", "scan-2024-01-02.2024-01-02T03-04-05.log", true},
+		{"scan-%Y-%m-%d.log", "scan-2024-01-02.2024-01-02T03-04-05.log.gz", true},
+		{"scan-%Y-%m-%d.log", "scan-2024-01-02.old.log", false},
+		{"scan-%Y-%m-%d.log", "scan-config.log", false},
+		{"scan-%Y-%m-%d.log", "scan-2024-01-02.txt", false},
+		{"%Y-%m-%d.log", "2024-01-02.log", true},
+		{"%Y-%m-%d.log", "notes.txt", false},
+		{"%Y-%m-%d.log", "data.json", false},
+		{"%Y-%m-%d.log", "2024-1-2.log", false},
+		{"app-%H%M.%y", "app-0304.24", true},
+		{"app-%H%M.%y", "app-0304.log", false},
+		{"100%%-%d.log", "100%-02.log", true},
+	}
+	for _, test := range tests {
+		w := &FileWithRotation{
+			options: &FileWithRotationOptions{
+				FileName:         test.pattern,
+				BackupTimeFormat: "2006-01-02T15-04-05",
+			},
+			timePattern: timePatternRegexp(test.pattern),
+		}
+		if got := w.isBackup(test.name); got != test.backup {
+			t.Errorf("isBackup(%q) with %q = %v, want %v", test.name, test.pattern, got, test.backup)
+		}
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-time.Hour)
+	files := []string{
+		"app.yaml",
+		"app.notes.log",
+		"app.2024-01-01T00-00-00.log",
+		"app.2024-01-02T00-00-00.log.gz",
+	}
+	for i, name := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("data\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := old.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:         dir,
+		FileName:         "app.log",
+		BackupTimeFormat: "2006-01-02T15-04-05",
+		MaxBackups:       1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if err := w.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var backups []string
+	remaining := make(map[string]bool)
+	for _, entry := range entries {
+		remaining[entry.Name()] = true
+		if w.isBackup(entry.Name()) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	for _, name := range []string{"app.log", "app.yaml", "app.notes.log"} {
+		if !remaining[name] {
+			t.Errorf("%s was removed", name)
+		}
+	}
+	if len(backups) != 1 || backups[0] == "app.2024-01-01T00-00-00.log" || backups[0] == "app.2024-01-02T00-00-00.log.gz" {
+		t.Errorf("expected only the new backup to be kept, got %v", backups)
+	}
+}
+
+func TestPruneBackupsTimePattern(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-time.Hour)
+	for i, name := range []string{"notes.txt", "data.json", "2000-01-01.log", "2000-01-02.log"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("data\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := old.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:         dir,
+		FileName:         "%Y-%m-%d.log",
+		BackupTimeFormat: "2006-01-02T15-04-05",
+		MaxBackups:       1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.pruneBackups(w.fileName)
+
+	for name, kept := range map[string]bool{
+		"notes.txt":      true,
+		"data.json":      true,
+		"2000-01-01.log": false,
+		"2000-01-02.log": true,
+		w.fileName:       true,
+	} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists := err == nil; exists != kept {
+			t.Errorf("%s: exists = %v, want %v", name, exists, kept)
+		}
+	}
+}
+
+func TestLowDiskSpaceDropsDiagnosticLevels(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:       dir,
+		FileName:       "app.log",
+		MinFreeSpace:   1 << 40,
+		OnLowDiskSpace: func(available uint64) {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	for _, level := range levels.All() {
+		w.Write([]byte(level.String()), level)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, level := range levels.All() {
+		written := strings.Contains(string(data), level.String()+"\n")
+		if written == level.IsDiagnostic() {
+			t.Errorf("%s: written = %v with low disk space", level, written)
+		}
+	}
+}
+
+func TestCheckAndRotate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options FileWithRotationOptions
+		size    int
+		rotated bool
+	}{
+		{"below max size", FileWithRotationOptions{MaxSize: 1}, 1024, false},
+		{"max size reached", FileWithRotationOptions{MaxSize: 1}, 1024 * 1024, true},
+		{"interval elapsed", FileWithRotationOptions{RotationInterval: time.Nanosecond}, 1024, true},
+		{"interval pending", FileWithRotationOptions{RotationInterval: time.Hour}, 1024, false},
+		{"no trigger", FileWithRotationOptions{}, 1024 * 1024, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			options := test.options
+			options.Location = dir
+			options.FileName = "app.log"
+			options.BackupTimeFormat = "2006-01-02T15-04-05"
+			w, err := NewFileWithRotation(&options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Close()
+
+			w.Write([]byte(strings.Repeat("x", test.size)), levels.LevelInfo)
+			time.Sleep(time.Millisecond)
+			w.checkAndRotate()
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rotated []string
+			for _, entry := range entries {
+				if entry.Name() != "app.log" {
+					rotated = append(rotated, entry.Name())
+				}
+			}
+			if len(rotated) > 1 || (len(rotated) == 1) != test.rotated {
+				t.Errorf("got rotated files %v, want rotated = %v", rotated, test.rotated)
+			}
+		})
+	}
+}
+
+func TestCloseStopsRotation(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:         dir,
+		FileName:         "app.log",
+		BackupTimeFormat: "2006-01-02T15-04-05.000000000",
+		Rotate:           true,
+		rotationcheck:    time.Millisecond,
+		RotationInterval: time.Nanosecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the scheduler rotates the file while it is written to
+	for i := 0; i < 50; i++ {
+		w.Write([]byte("event"), levels.LevelInfo)
+		time.Sleep(100 * time.Microsecond)
+	}
+	w.Close()
+	w.Close()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	after, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(entries) {
+		t.Errorf("%d files before and %d files after waiting, the writer kept rotating after Close", len(entries), len(after))
+	}
+
+	w.Write([]byte("late"), levels.LevelInfo)
+	if dropped := w.Dropped(); dropped != 1 {
+		t.Errorf("dropped %d events, want the write after Close", dropped)
+	}
+	if err := w.Flush(); err != ErrClosed {
+		t.Errorf("Flush() = %v, want ErrClosed", err)
+	}
+	if err := w.Rotate(); err != ErrClosed {
+		t.Errorf("Rotate() = %v, want ErrClosed", err)
+	}
+}
+
+// TestRotationWhileRotating is meant to be run with -race
+func TestRotationWhileRotating(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewFileWithRotation(&FileWithRotationOptions{
+		Location:         dir,
+		FileName:         "app.log",
+		BackupTimeFormat: "2006-01-02T15-04-05.000000000",
+		Rotate:           true,
+		rotationcheck:    time.Millisecond,
+		MaxSize:          1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// forced rotations swap the file checked by the scheduler
+	for i := 0; i < 20; i++ {
+		w.Write([]byte("event"), levels.LevelInfo)
+		if err := w.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,161 @@
+package writer
+
+import (
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Follow is a concurrent writer forwarding the events to a writer (usually
+// a FileWithRotation) and publishing them to subscribers, like tail -f on
+// the log file. It lets an embedded web UI or TUI live-tail the logs of the
+// same process without reopening and parsing the file.
+type Follow struct {
+	writer      Writer
+	mutex       *sync.Mutex
+	backlog     []FollowLine
+	size        int
+	subscribers map[chan FollowLine]struct{}
+	closed      bool
+}
+
+var (
+	_ Writer       = &Follow{}
+	_ Capabilities = &Follow{}
+)
+
+// FollowLine is an event published to the subscribers
+type FollowLine struct {
+	Level levels.Level
+	// Data is a copy of the formatted event
+	Data []byte
+}
+
+// NewFollow returns a writer publishing the events written to w, the last
+// backlog events being replayed to new subscribers. w can be nil to only
+// publish the events.
+func NewFollow(w Writer, backlog int) *Follow {
+	if backlog < 0 {
+		backlog = 0
+	}
+	return &Follow{
+		writer:      w,
+		mutex:       &sync.Mutex{},
+		size:        backlog,
+		subscribers: make(map[chan FollowLine]struct{}),
+	}
+}
+
+// Write writes the data to the wrapped writer and publishes it to the
+// subscribers. Subscribers not keeping up miss the events instead of
+// blocking the logger.
+func (f *Follow) Write(data []byte, level levels.Level) {
+	if f.writer != nil {
+		f.writer.Write(data, level)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.closed || (f.size == 0 && len(f.subscribers) == 0) {
+		return
+	}
+	line := FollowLine{Level: level, Data: append([]byte(nil), data...)}
+	if f.size > 0 {
+		if len(f.backlog) == f.size {
+			copy(f.backlog, f.backlog[1:])
+			f.backlog = f.backlog[:f.size-1]
+		}
+		f.backlog = append(f.backlog, line)
+	}
+	for ch := range f.subscribers {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the backlog followed by the new
+// events, and a function to call to unsubscribe, which closes the channel.
+// buffer is the number of new events that can be queued for the subscriber.
+func (f *Follow) Subscribe(buffer int) (<-chan FollowLine, func()) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if buffer < 0 {
+		buffer = 0
+	}
+	ch := make(chan FollowLine, len(f.backlog)+buffer)
+	for _, line := range f.backlog {
+		ch <- line
+	}
+	if f.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	f.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+
+			if _, ok := f.subscribers[ch]; ok {
+				delete(f.subscribers, ch)
+				close(ch)
+			}
+		})
+	}
+}
+
+// Flush flushes the wrapped writer
+func (f *Follow) Flush() error {
+	if flusher, ok := f.writer.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Close closes the subscriber channels and the wrapped writer
+func (f *Follow) Close() error {
+	f.mutex.Lock()
+	if !f.closed {
+		f.closed = true
+		for ch := range f.subscribers {
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+	f.mutex.Unlock()
+
+	if f.writer == nil {
+		return nil
+	}
+	return Close(f.writer)
+}
+
+// SupportsColor returns the color support of the wrapped writer
+func (f *Follow) SupportsColor() bool {
+	if c, ok := f.writer.(Capabilities); ok {
+		return c.SupportsColor()
+	}
+	return false
+}
+
+// IsTerminal returns whether the wrapped writer is a terminal
+func (f *Follow) IsTerminal() bool {
+	if c, ok := f.writer.(Capabilities); ok {
+		return c.IsTerminal()
+	}
+	return false
+}
+
+// PrefersJSON returns whether the wrapped writer prefers JSON events
+func (f *Follow) PrefersJSON() bool {
+	if c, ok := f.writer.(Capabilities); ok {
+		return c.PrefersJSON()
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package writer
+
+import (
+	"crypto/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+const (
+	// DefaultGELFChunkSize is the default maximum size of the GELF UDP datagrams
+	DefaultGELFChunkSize = 1420
+	// gelfChunkHeaderSize is the size of the magic bytes, message id, sequence number and count
+	gelfChunkHeaderSize = 12
+	// gelfMaxChunks is the maximum number of chunks of a message
+	gelfMaxChunks = 128
+)
+
+// GELF is a concurrent output writer sending GELF messages to Graylog over
+// UDP, splitting the messages larger than the chunk size into chunks.
+// It is meant to be used with the GELF formatter.
+type GELF struct {
+	mutex     *sync.Mutex
+	conn      net.Conn
+	chunkSize int
+	dropped   atomic.Uint64
+}
+
+var (
+	_ Writer       = &GELF{}
+	_ Capabilities = &GELF{}
+)
+
+// NewGELF returns a new GELF UDP writer sending messages to addr
+func NewGELF(addr string) (*GELF, error) {
+	return NewGELFWithChunkSize(addr, DefaultGELFChunkSize)
+}
+
+// NewGELFWithChunkSize returns a new GELF UDP writer sending datagrams of
+// at most chunkSize bytes to addr
+func NewGELFWithChunkSize(addr string, chunkSize int) (*GELF, error) {
+	if chunkSize <= gelfChunkHeaderSize {
+		chunkSize = DefaultGELFChunkSize
+	}
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &GELF{mutex: &sync.Mutex{}, conn: conn, chunkSize: chunkSize}, nil
+}
+
+// Write sends the data as a GELF message, chunked if needed.
+// Messages needing more than 128 chunks are dropped.
+func (w *GELF) Write(data []byte, level levels.Level) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(data) <= w.chunkSize {
+		_, _ = w.conn.Write(data)
+		return
+	}
+
+	payloadSize := w.chunkSize - gelfChunkHeaderSize
+	count := (len(data) + payloadSize - 1) / payloadSize
+	if count > gelfMaxChunks {
+		w.dropped.Add(1)
+		return
+	}
+
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	chunk := make([]byte, 0, w.chunkSize)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payloadSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk = append(chunk[:0], 0x1e, 0x0f)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, data[i*payloadSize:end]...)
+		if _, err := w.conn.Write(chunk); err != nil {
+			return
+		}
+	}
+}
+
+// Dropped returns the number of messages dropped for being too large
+func (w *GELF) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close closes the connection
+func (w *GELF) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.conn.Close()
+}
+
+// SupportsColor returns false as GELF messages are plain text
+func (w *GELF) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the output is a Graylog server
+func (w *GELF) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns true, the GELF formatter is expected though
+func (w *GELF) PrefersJSON() bool {
+	return true
+}
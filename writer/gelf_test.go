@@ -0,0 +1,85 @@
+package writer
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// receiveDatagrams reads n datagrams from the listener
+func receiveDatagrams(t *testing.T, conn net.PacketConn, n int) [][]byte {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var datagrams [][]byte
+	buffer := make([]byte, 65536)
+	for len(datagrams) < n {
+		size, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		datagrams = append(datagrams, append([]byte(nil), buffer[:size]...))
+	}
+	return datagrams
+}
+
+func TestGELFChunking(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	w, err := NewGELFWithChunkSize(listener.LocalAddr().String(), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	small := []byte(`{"short_message":"a"}`)
+	w.Write(small, levels.LevelInfo)
+	if got := receiveDatagrams(t, listener, 1)[0]; !bytes.Equal(got, small) {
+		t.Fatalf("got %q, want the message unchunked", got)
+	}
+
+	large := []byte(`{"short_message":"` + strings.Repeat("x", 100) + `"}`)
+	w.Write(large, levels.LevelInfo)
+	// 20 bytes of payload per chunk
+	chunks := receiveDatagrams(t, listener, 6)
+	var message []byte
+	for i, chunk := range chunks {
+		if len(chunk) > 32 {
+			t.Errorf("chunk %d is %d bytes", i, len(chunk))
+		}
+		if chunk[0] != 0x1e || chunk[1] != 0x0f || !bytes.Equal(chunk[2:10], chunks[0][2:10]) {
+			t.Fatalf("chunk %d has header % x", i, chunk[:12])
+		}
+		if chunk[10] != byte(i) || chunk[11] != 6 {
+			t.Errorf("chunk %d is numbered %d of %d", i, chunk[10], chunk[11])
+		}
+		message = append(message, chunk[12:]...)
+	}
+	if !bytes.Equal(message, large) {
+		t.Errorf("reassembled %q", message)
+	}
+}
+
+func TestGELFTooManyChunks(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	w, err := NewGELFWithChunkSize(listener.LocalAddr().String(), 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	w.Write(bytes.Repeat([]byte("x"), 4*129), levels.LevelInfo)
+	if dropped := w.Dropped(); dropped != 1 {
+		t.Errorf("dropped %d messages, want 1", dropped)
+	}
+}
@@ -0,0 +1,195 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// JournaldSocket is the path of the systemd journal native protocol socket
+const JournaldSocket = "/run/systemd/journal/socket"
+
+// Journald is a concurrent output writer sending entries to the systemd
+// journal with its native protocol.
+//
+// When the formatted data is a JSON object (as produced by the JSON formatter),
+// the msg field becomes the MESSAGE of the entry and the other fields are sent
+// as journal fields, e.g. host becomes HOST, otherwise the whole data is used
+// as message. The entry priority is derived from the event level.
+type Journald struct {
+	mutex      *sync.Mutex
+	identifier string
+	conn       *net.UnixConn
+	addr       *net.UnixAddr
+	dropped    atomic.Uint64
+}
+
+var (
+	_ Writer       = &Journald{}
+	_ Capabilities = &Journald{}
+)
+
+// NewJournald returns a new journald writer tagging the entries with the
+// identifier as SYSLOG_IDENTIFIER, if not empty.
+func NewJournald(identifier string) (*Journald, error) {
+	return NewJournaldWithSocket(JournaldSocket, identifier)
+}
+
+// NewJournaldWithSocket returns a new journald writer sending the entries
+// to the socket at path
+func NewJournaldWithSocket(path, identifier string) (*Journald, error) {
+	if info, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if info.Mode()&os.ModeSocket == 0 {
+		return nil, fmt.Errorf("%s is not a socket", path)
+	}
+	// the entries are sent unconnected so that the writer recovers from
+	// journald restarts
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &Journald{
+		mutex:      &sync.Mutex{},
+		identifier: identifier,
+		conn:       conn,
+		addr:       &net.UnixAddr{Name: path, Net: "unixgram"},
+	}, nil
+}
+
+// Write sends the data as a journal entry
+func (w *Journald) Write(data []byte, level levels.Level) {
+	entry := w.format(data, level)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn == nil {
+		w.dropped.Add(1)
+		return
+	}
+	if _, err := w.conn.WriteToUnix(entry, w.addr); err != nil {
+		w.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of entries that could not be sent to journald
+func (w *Journald) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close closes the journald socket
+func (w *Journald) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+}
+
+// format builds the native protocol entry
+func (w *Journald) format(data []byte, level levels.Level) []byte {
+	priority, ok := syslogSeverities[level]
+	if !ok {
+		priority = 6
+	}
+	message, fields := string(data), map[string]interface{}(nil)
+	if parsed, ok := parseJSONObject(data); ok {
+		fields = parsed
+		if m, ok := fields["msg"].(string); ok {
+			message = m
+			delete(fields, "msg")
+		}
+		// journald records its own timestamp for each entry
+		delete(fields, "timestamp")
+	}
+
+	buffer := &bytes.Buffer{}
+	writeJournaldField(buffer, "MESSAGE", message)
+	writeJournaldField(buffer, "PRIORITY", fmt.Sprint(priority))
+	if w.identifier != "" {
+		writeJournaldField(buffer, "SYSLOG_IDENTIFIER", w.identifier)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := journaldFieldName(k)
+		if name == "" || name == "MESSAGE" || name == "PRIORITY" || name == "SYSLOG_IDENTIFIER" {
+			continue
+		}
+		value, ok := fields[k].(string)
+		if !ok {
+			encoded, _ := json.Marshal(fields[k])
+			value = string(encoded)
+		}
+		writeJournaldField(buffer, name, value)
+	}
+	return buffer.Bytes()
+}
+
+// writeJournaldField writes a field, using the binary form for values
+// spanning several lines
+func writeJournaldField(buffer *bytes.Buffer, name, value string) {
+	buffer.WriteString(name)
+	if strings.IndexByte(value, '\n') < 0 {
+		buffer.WriteByte('=')
+		buffer.WriteString(value)
+		buffer.WriteByte('\n')
+		return
+	}
+	buffer.WriteByte('\n')
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value)
+	buffer.WriteByte('\n')
+}
+
+// journaldFieldName converts a key to a journal field name made of
+// uppercase letters, digits and underscores, not starting with an
+// underscore which is reserved for trusted fields
+func journaldFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_0123456789")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// SupportsColor returns false as journal entries are plain text
+func (w *Journald) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the output is the journal
+func (w *Journald) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns true so that metadata becomes journal fields
+func (w *Journald) PrefersJSON() bool {
+	return true
+}
@@ -0,0 +1,24 @@
+//go:build linux
+
+package writer
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// IsJournalStream reports whether f is connected to the systemd journal,
+// i.e. whether the process is run by systemd with its output sent to the
+// journal and the JOURNAL_STREAM variable matches the device and inode of f.
+func IsJournalStream(f *os.File) bool {
+	stream := os.Getenv("JOURNAL_STREAM")
+	if stream == "" {
+		return false
+	}
+	var stat syscall.Stat_t
+	if err := syscall.Fstat(int(f.Fd()), &stat); err != nil {
+		return false
+	}
+	return stream == fmt.Sprintf("%d:%d", stat.Dev, stat.Ino)
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package writer
+
+import "os"
+
+// IsJournalStream returns false as the systemd journal is only available on Linux
+func IsJournalStream(f *os.File) bool {
+	return false
+}
@@ -0,0 +1,59 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestJournald(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets unavailable: %v", err)
+	}
+	defer conn.Close()
+
+	w, err := NewJournaldWithSocket(path, "nuclei")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte(`{"timestamp":"2024-01-01T00:00:00+0000","level":"error","msg":"request failed","host":"example.com","status-code":503,"body":"a\nb"}`), levels.LevelError)
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buffer := make([]byte, 4096)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], 3)
+	want := "MESSAGE=request failed\nPRIORITY=3\nSYSLOG_IDENTIFIER=nuclei\n" +
+		"BODY\n" + string(length[:]) + "a\nb\n" +
+		"HOST=example.com\nLEVEL=error\nSTATUS_CODE=503\n"
+	if got := buffer[:n]; !bytes.Equal(got, []byte(want)) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if w.Dropped() != 0 {
+		t.Errorf("dropped = %d, want 0", w.Dropped())
+	}
+
+	// the entries are dropped while journald is down
+	conn.Close()
+	w.Write([]byte("plain"), levels.LevelInfo)
+	if w.Dropped() != 1 {
+		t.Errorf("dropped = %d, want 1", w.Dropped())
+	}
+}
+
+func TestJournaldMissingSocket(t *testing.T) {
+	if _, err := NewJournaldWithSocket(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+		t.Error("expected an error without the journald socket")
+	}
+}
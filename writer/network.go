@@ -0,0 +1,183 @@
+package writer
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// NetworkOptions configures the network writer
+type NetworkOptions struct {
+	// DialTimeout is the maximum duration of a connection attempt
+	DialTimeout time.Duration
+	// WriteTimeout is the maximum duration of a write on the connection
+	WriteTimeout time.Duration
+	// ReconnectInterval is the minimum duration between reconnection attempts
+	ReconnectInterval time.Duration
+	// BufferSize is the number of entries kept in memory while disconnected,
+	// the oldest entries are dropped once it is full
+	BufferSize int
+}
+
+// DefaultNetworkOptions are the options used by NewNetwork
+var DefaultNetworkOptions = NetworkOptions{
+	DialTimeout:       5 * time.Second,
+	WriteTimeout:      5 * time.Second,
+	ReconnectInterval: time.Second,
+	BufferSize:        1024,
+}
+
+// Network is a concurrent output writer streaming newline delimited entries
+// to a remote tcp or udp socket, such as Logstash or Vector inputs.
+//
+// Entries written while the connection is down are kept in a bounded
+// in-memory buffer and sent once the connection is established again.
+type Network struct {
+	mutex       *sync.Mutex
+	network     string
+	addr        string
+	options     NetworkOptions
+	conn        net.Conn
+	lastAttempt time.Time
+	pending     [][]byte
+	dropped     atomic.Uint64
+}
+
+var (
+	_ Writer       = &Network{}
+	_ Capabilities = &Network{}
+)
+
+// NewNetwork returns a new network writer connected to addr over network
+// (tcp or udp) with the default options.
+func NewNetwork(network, addr string) (*Network, error) {
+	return NewNetworkWithOptions(network, addr, DefaultNetworkOptions)
+}
+
+// NewNetworkWithOptions returns a new network writer connected to addr over network
+func NewNetworkWithOptions(network, addr string, options NetworkOptions) (*Network, error) {
+	w := &Network{
+		mutex:   &sync.Mutex{},
+		network: network,
+		addr:    addr,
+		options: options,
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write sends the data followed by a newline, buffering it if the
+// connection is down
+func (w *Network) Write(data []byte, level levels.Level) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	line := make([]byte, 0, len(data)+len(NewLine))
+	line = append(line, data...)
+	line = append(line, NewLine...)
+
+	w.buffer(line)
+	w.send()
+}
+
+// Flush tries to send the buffered entries, reconnecting if needed
+func (w *Network) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.send()
+}
+
+// Dropped returns the number of entries dropped because the buffer was full
+func (w *Network) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// QueueDepth returns the number of entries waiting to be sent
+func (w *Network) QueueDepth() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return len(w.pending)
+}
+
+// Close closes the connection, the buffered entries are discarded
+func (w *Network) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+}
+
+// buffer appends the line to the pending entries, dropping the oldest
+// one when the buffer is full
+func (w *Network) buffer(line []byte) {
+	size := w.options.BufferSize
+	if size <= 0 {
+		size = 1
+	}
+	if len(w.pending) >= size {
+		w.pending[0] = nil
+		w.pending = w.pending[1:]
+		w.dropped.Add(1)
+	}
+	w.pending = append(w.pending, line)
+}
+
+// send writes the pending entries in order, stopping at the first failure
+func (w *Network) send() error {
+	if w.conn == nil {
+		if time.Since(w.lastAttempt) < w.options.ReconnectInterval {
+			return net.ErrClosed
+		}
+		if err := w.connect(); err != nil {
+			return err
+		}
+	}
+	for len(w.pending) > 0 {
+		if w.options.WriteTimeout > 0 {
+			_ = w.conn.SetWriteDeadline(time.Now().Add(w.options.WriteTimeout))
+		}
+		if _, err := w.conn.Write(w.pending[0]); err != nil {
+			w.conn.Close()
+			w.conn = nil
+			return err
+		}
+		w.pending[0] = nil
+		w.pending = w.pending[1:]
+	}
+	return nil
+}
+
+func (w *Network) connect() error {
+	w.lastAttempt = time.Now()
+	conn, err := net.DialTimeout(w.network, w.addr, w.options.DialTimeout)
+	if err != nil {
+		return err
+	}
+	w.conn = conn
+	return nil
+}
+
+// SupportsColor returns false as the entries are shipped to remote collectors
+func (w *Network) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the output is a socket
+func (w *Network) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns true as collectors expect structured entries
+func (w *Network) PrefersJSON() bool {
+	return true
+}
@@ -0,0 +1,95 @@
+package writer
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// readLines reads n newline delimited entries from the connection
+func readLines(t *testing.T, conn net.Conn, n int) []string {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	scanner := bufio.NewScanner(conn)
+	var lines []string
+	for len(lines) < n && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func TestNetworkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	w, err := NewNetwork("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	w.Write([]byte(`{"msg":"first"}`), levels.LevelInfo)
+	w.Write([]byte(`{"msg":"second"}`), levels.LevelInfo)
+	if lines := readLines(t, conn, 2); len(lines) != 2 || lines[0] != `{"msg":"first"}` || lines[1] != `{"msg":"second"}` {
+		t.Errorf("got %q", lines)
+	}
+}
+
+func TestNetworkOutageBuffer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	w, err := NewNetworkWithOptions("tcp", addr, NetworkOptions{DialTimeout: time.Second, BufferSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	// the collector goes away
+	listener.Close()
+	w.conn.Close()
+
+	for _, line := range []string{"a", "b", "c"} {
+		w.Write([]byte(line), levels.LevelInfo)
+	}
+	if depth, dropped := w.QueueDepth(), w.Dropped(); depth != 2 || dropped != 1 {
+		t.Fatalf("queue depth %d and dropped %d, want 2 and 1", depth, dropped)
+	}
+
+	// and comes back, the buffered entries are sent once reconnected
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("cannot listen again on %s: %s", addr, err)
+	}
+	defer listener.Close()
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if lines := readLines(t, conn, 2); len(lines) != 2 || lines[0] != "b" || lines[1] != "c" {
+		t.Errorf("got %q, want the 2 most recent entries", lines)
+	}
+	if depth := w.QueueDepth(); depth != 0 {
+		t.Errorf("%d entries still queued", depth)
+	}
+}
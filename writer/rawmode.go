@@ -0,0 +1,160 @@
+package writer
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// DefaultRawModeBufferSize is the number of lines held by default while
+// the terminal is in raw mode
+const DefaultRawModeBufferSize = 1000
+
+// RawModeAware is a concurrent writer wrapping a terminal writer. While the
+// terminal is in raw mode, as set by interactive TUIs, lines are held in a
+// buffer or sent to a fallback writer instead of corrupting the screen, and
+// buffered lines are written once cooked mode resumes.
+type RawModeAware struct {
+	mutex      *sync.Mutex
+	writer     Writer
+	fallback   Writer
+	detect     bool
+	raw        bool
+	bufferSize int
+	pending    []rawModeLine
+	dropped    atomic.Uint64
+}
+
+type rawModeLine struct {
+	data  []byte
+	level levels.Level
+}
+
+var _ Writer = &RawModeAware{}
+
+// NewRawModeAware returns a new writer holding the lines written to w while
+// the terminal attached to stdin is in raw mode
+func NewRawModeAware(w Writer) *RawModeAware {
+	return &RawModeAware{
+		mutex:      &sync.Mutex{},
+		writer:     w,
+		detect:     true,
+		bufferSize: DefaultRawModeBufferSize,
+	}
+}
+
+// SetDetection enables or disables the automatic raw mode detection
+func (w *RawModeAware) SetDetection(enabled bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.detect = enabled
+}
+
+// SetRawMode toggles raw mode explicitly, for applications knowing when
+// their TUI is active. Leaving raw mode writes the buffered lines.
+func (w *RawModeAware) SetRawMode(raw bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.raw = raw
+	if !w.isRaw() {
+		w.replay()
+	}
+}
+
+// SetFallback sets a writer (e.g. a file) receiving the lines while in raw
+// mode instead of the buffer
+func (w *RawModeAware) SetFallback(fallback Writer) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.fallback = fallback
+}
+
+// SetBufferSize sets the number of lines held while in raw mode, the
+// oldest lines being dropped once it is full
+func (w *RawModeAware) SetBufferSize(size int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.bufferSize = size
+}
+
+// Write writes the data to the wrapped writer, or holds it in raw mode
+func (w *RawModeAware) Write(data []byte, level levels.Level) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.isRaw() {
+		if w.fallback != nil {
+			w.fallback.Write(data, level)
+			return
+		}
+		if len(w.pending) >= w.bufferSize {
+			if w.bufferSize <= 0 {
+				w.dropped.Add(1)
+				return
+			}
+			w.pending = w.pending[1:]
+			w.dropped.Add(1)
+		}
+		w.pending = append(w.pending, rawModeLine{data: append([]byte(nil), data...), level: level})
+		return
+	}
+	w.replay()
+	w.writer.Write(data, level)
+}
+
+// Flush writes the buffered lines if the terminal is back in cooked mode
+func (w *RawModeAware) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.isRaw() {
+		w.replay()
+	}
+	return nil
+}
+
+// Close writes the buffered lines, even in raw mode as the output is
+// ending, and closes the wrapped writers
+func (w *RawModeAware) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.replay()
+	err := Close(w.writer)
+	if w.fallback != nil {
+		if fallbackErr := Close(w.fallback); err == nil {
+			err = fallbackErr
+		}
+	}
+	return err
+}
+
+// Dropped returns the number of lines dropped because the buffer was full
+func (w *RawModeAware) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// QueueDepth returns the number of lines held while in raw mode
+func (w *RawModeAware) QueueDepth() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return len(w.pending)
+}
+
+func (w *RawModeAware) isRaw() bool {
+	return w.raw || (w.detect && IsRawMode(os.Stdin))
+}
+
+func (w *RawModeAware) replay() {
+	for _, line := range w.pending {
+		w.writer.Write(line.data, line.level)
+	}
+	w.pending = nil
+}
@@ -0,0 +1,17 @@
+//go:build linux
+
+package writer
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// IsRawMode reports whether the terminal attached to the file has line
+// editing disabled, as done by interactive TUIs
+func IsRawMode(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0 && termios.Lflag&syscall.ICANON == 0
+}
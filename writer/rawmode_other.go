@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !windows
+
+package writer
+
+import "os"
+
+// IsRawMode always returns false as raw mode cannot be detected on this platform
+func IsRawMode(f *os.File) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+//go:build windows
+
+package writer
+
+import (
+	"os"
+	"syscall"
+)
+
+const enableLineInput = 0x0002
+
+// IsRawMode reports whether the console attached to the file has line
+// input disabled, as done by interactive TUIs
+func IsRawMode(f *os.File) bool {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(f.Fd()), &mode); err != nil {
+		return false
+	}
+	return mode&enableLineInput == 0
+}
@@ -0,0 +1,183 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Ring is a concurrent writer keeping the most recent events in memory.
+//
+// Checkpoint events (JSON events with a "checkpoint" field) are also
+// indexed by name and kept after being evicted from the buffer.
+type Ring struct {
+	mutex       *sync.Mutex
+	entries     []ringEntry
+	next        int
+	full        bool
+	checkpoints map[string]ringEntry
+}
+
+var (
+	_ Writer       = &Ring{}
+	_ Capabilities = &Ring{}
+)
+
+type ringEntry struct {
+	time  time.Time
+	level levels.Level
+	data  []byte
+}
+
+// RingEntry is a parsed event stored in the ring buffer
+type RingEntry struct {
+	Time  time.Time
+	Level levels.Level
+	// Data is the formatted event as written by the formatter
+	Data []byte
+	// Message and Fields are populated when Data is a JSON object,
+	// otherwise Message holds the whole formatted line.
+	Message string
+	Fields  map[string]interface{}
+}
+
+// NewRing returns a new ring buffer writer holding up to size events.
+func NewRing(size int) *Ring {
+	if size <= 0 {
+		size = 1
+	}
+	return &Ring{mutex: &sync.Mutex{}, entries: make([]ringEntry, size)}
+}
+
+// Write stores a copy of the data in the ring buffer
+func (r *Ring) Write(data []byte, level levels.Level) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := ringEntry{time: time.Now(), level: level, data: append([]byte(nil), data...)}
+	r.entries[r.next] = entry
+	if bytes.Contains(data, []byte(`"checkpoint":`)) {
+		if name, ok := parseRingEntry(entry).Fields["checkpoint"].(string); ok {
+			if r.checkpoints == nil {
+				r.checkpoints = make(map[string]ringEntry)
+			}
+			r.checkpoints[name] = entry
+		}
+	}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Entries returns all the stored entries from the oldest to the newest
+func (r *Ring) Entries() []RingEntry {
+	return r.filter(func(ringEntry) bool { return true })
+}
+
+// ByLevel returns the stored entries with the given level
+func (r *Ring) ByLevel(level levels.Level) []RingEntry {
+	return r.filter(func(entry ringEntry) bool { return entry.level == level })
+}
+
+// Since returns the stored entries written after t
+func (r *Ring) Since(t time.Time) []RingEntry {
+	return r.filter(func(entry ringEntry) bool { return entry.time.After(t) })
+}
+
+// ByKey returns the stored entries having a field key with the given value
+func (r *Ring) ByKey(key, value string) []RingEntry {
+	var matches []RingEntry
+	for _, entry := range r.Entries() {
+		if v, ok := entry.Fields[key]; ok && fmt.Sprint(v) == value {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// Checkpoints returns the latest checkpoint entry of each name from the
+// oldest to the newest, including the ones evicted from the buffer
+func (r *Ring) Checkpoints() []RingEntry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries := make([]RingEntry, 0, len(r.checkpoints))
+	for _, entry := range r.checkpoints {
+		entries = append(entries, parseRingEntry(entry))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries
+}
+
+// Checkpoint returns the latest checkpoint entry with the given name
+func (r *Ring) Checkpoint(name string) (RingEntry, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.checkpoints[name]
+	if !ok {
+		return RingEntry{}, false
+	}
+	return parseRingEntry(entry), true
+}
+
+// filter returns the parsed entries matching the filter in insertion order
+func (r *Ring) filter(match func(ringEntry) bool) []RingEntry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var stored []ringEntry
+	if r.full {
+		stored = append(stored, r.entries[r.next:]...)
+	}
+	stored = append(stored, r.entries[:r.next]...)
+
+	var entries []RingEntry
+	for _, entry := range stored {
+		if match(entry) {
+			entries = append(entries, parseRingEntry(entry))
+		}
+	}
+	return entries
+}
+
+func parseRingEntry(entry ringEntry) RingEntry {
+	parsed := RingEntry{Time: entry.time, Level: entry.level, Data: entry.data, Message: string(entry.data)}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(entry.data), []byte("{")) {
+		return parsed
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(entry.data, &fields); err != nil {
+		return parsed
+	}
+	if msg, ok := fields["msg"].(string); ok {
+		parsed.Message = msg
+		delete(fields, "msg")
+	}
+	parsed.Fields = fields
+	return parsed
+}
+
+// SupportsColor returns false so that stored entries stay readable
+func (r *Ring) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as entries are kept in memory
+func (r *Ring) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns true so that entries can be parsed and queried
+func (r *Ring) PrefersJSON() bool {
+	return true
+}
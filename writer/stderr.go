@@ -0,0 +1,53 @@
+package writer
+
+import (
+	"os"
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Stderr is a concurrent output writer to stderr for all levels.
+type Stderr struct {
+	mutex *sync.Mutex
+}
+
+var (
+	_ Writer       = &Stderr{}
+	_ Capabilities = &Stderr{}
+	_ Streamer     = &Stderr{}
+)
+
+// NewStderr returns a new Stderr concurrent log writer.
+func NewStderr() *Stderr {
+	return &Stderr{mutex: &sync.Mutex{}}
+}
+
+// Write writes an output to stderr
+func (w *Stderr) Write(data []byte, level levels.Level) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	os.Stderr.Write(data)
+	os.Stderr.WriteString(NewLine)
+}
+
+// Stream returns stderr for all the levels
+func (w *Stderr) Stream(level levels.Level) *os.File {
+	return os.Stderr
+}
+
+// SupportsColor reports whether colored output can be rendered on stderr
+func (w *Stderr) SupportsColor() bool {
+	return SupportsColor(os.Stderr)
+}
+
+// IsTerminal reports whether the output is attached to a terminal
+func (w *Stderr) IsTerminal() bool {
+	return IsTerminal(os.Stderr)
+}
+
+// PrefersJSON returns false, terminal output is meant for humans
+func (w *Stderr) PrefersJSON() bool {
+	return false
+}
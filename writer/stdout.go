@@ -0,0 +1,53 @@
+package writer
+
+import (
+	"os"
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Stdout is a concurrent output writer to stdout for all levels.
+type Stdout struct {
+	mutex *sync.Mutex
+}
+
+var (
+	_ Writer       = &Stdout{}
+	_ Capabilities = &Stdout{}
+	_ Streamer     = &Stdout{}
+)
+
+// NewStdout returns a new Stdout concurrent log writer.
+func NewStdout() *Stdout {
+	return &Stdout{mutex: &sync.Mutex{}}
+}
+
+// Write writes an output to stdout
+func (w *Stdout) Write(data []byte, level levels.Level) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	os.Stdout.Write(data)
+	os.Stdout.WriteString(NewLine)
+}
+
+// Stream returns stdout for all the levels
+func (w *Stdout) Stream(level levels.Level) *os.File {
+	return os.Stdout
+}
+
+// SupportsColor reports whether colored output can be rendered on stdout
+func (w *Stdout) SupportsColor() bool {
+	return SupportsColor(os.Stdout)
+}
+
+// IsTerminal reports whether the output is attached to a terminal
+func (w *Stdout) IsTerminal() bool {
+	return IsTerminal(os.Stdout)
+}
+
+// PrefersJSON returns false, terminal output is meant for humans
+func (w *Stdout) PrefersJSON() bool {
+	return false
+}
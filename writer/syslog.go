@@ -0,0 +1,315 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Syslog facilities as defined in RFC 5424
+const (
+	FacilityKern   = 0
+	FacilityUser   = 1
+	FacilityDaemon = 3
+	FacilityAuth   = 4
+	FacilityLocal0 = 16
+	FacilityLocal1 = 17
+	FacilityLocal2 = 18
+	FacilityLocal3 = 19
+	FacilityLocal4 = 20
+	FacilityLocal5 = 21
+	FacilityLocal6 = 22
+	FacilityLocal7 = 23
+)
+
+// SyslogSDID is the default SD-ID used for the structured data built from event metadata
+const SyslogSDID = "meta@32473"
+
+// SyslogOptions configures the syslog writer
+type SyslogOptions struct {
+	Facility int
+	Tag      string
+	// SDID is the SD-ID of the element holding the event metadata,
+	// SyslogSDID by default. Private SD-IDs must be of the name@<private
+	// enterprise number> form.
+	SDID string
+	// FlattenMetadata disables structured data, JSON events are then sent
+	// as is in the message
+	FlattenMetadata bool
+}
+
+var syslogSeverities = map[levels.Level]int{
+	levels.LevelFatal:   2, // critical
+	levels.LevelSilent:  5, // notice
+	levels.LevelError:   3, // error
+	levels.LevelInfo:    6, // informational
+	levels.LevelWarning: 4, // warning
+	levels.LevelDebug:   7, // debug
+	levels.LevelVerbose: 7, // debug
+	levels.LevelTrace:   7, // debug
+	levels.LevelAudit:   5, // notice
+}
+
+// Syslog is a concurrent output writer shipping RFC 5424 messages to a syslog server.
+//
+// When the formatted data is a JSON object (as produced by the JSON formatter),
+// the msg field becomes the syslog message and the other fields are sent as
+// structured data, otherwise the whole data is used as message.
+//
+// When the connection fails, the writer reconnects in the background with an
+// exponential backoff and the messages written meanwhile are dropped.
+type Syslog struct {
+	mutex    *sync.Mutex
+	network  string
+	addr     string
+	facility int
+	tag      string
+	sdID     string
+	flatten  bool
+	hostname string
+	conn     net.Conn
+	// redialing is set while the background reconnection runs
+	redialing bool
+	closed    bool
+	done      chan struct{}
+	dropped   atomic.Uint64
+}
+
+// Syslog reconnection settings
+const (
+	syslogDialTimeout = 5 * time.Second
+	syslogMinBackoff  = 100 * time.Millisecond
+	syslogMaxBackoff  = 30 * time.Second
+)
+
+var (
+	_ Writer       = &Syslog{}
+	_ Capabilities = &Syslog{}
+)
+
+// NewSyslog returns a new syslog writer connected to addr over network
+// (udp, tcp, unix or unixgram).
+func NewSyslog(network, addr string, facility int, tag string) (*Syslog, error) {
+	return NewSyslogWithOptions(network, addr, SyslogOptions{Facility: facility, Tag: tag})
+}
+
+// NewSyslogWithOptions returns a new syslog writer connected to addr over network
+func NewSyslogWithOptions(network, addr string, options SyslogOptions) (*Syslog, error) {
+	if options.SDID == "" {
+		options.SDID = SyslogSDID
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	w := &Syslog{
+		mutex:    &sync.Mutex{},
+		network:  network,
+		addr:     addr,
+		facility: options.Facility,
+		tag:      options.Tag,
+		sdID:     sdName(options.SDID),
+		flatten:  options.FlattenMetadata,
+		hostname: hostname,
+		done:     make(chan struct{}),
+	}
+	conn, err := net.DialTimeout(network, addr, syslogDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	w.conn = conn
+	return w, nil
+}
+
+// Write sends the data as a syslog message, dropping it while the
+// connection is down
+func (w *Syslog) Write(data []byte, level levels.Level) {
+	message := w.format(data, level)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn != nil {
+		if _, err := w.conn.Write(message); err == nil {
+			return
+		}
+		w.conn.Close()
+		w.conn = nil
+	}
+	w.dropped.Add(1)
+	if !w.redialing && !w.closed {
+		w.redialing = true
+		go w.redial()
+	}
+}
+
+// Dropped returns the number of messages dropped while the connection was down
+func (w *Syslog) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close closes the connection to the syslog server and stops reconnecting
+func (w *Syslog) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.done)
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+}
+
+// redial reconnects to the syslog server with an exponential backoff, the
+// writer mutex is not held while dialing so that writes don't block
+func (w *Syslog) redial() {
+	backoff := syslogMinBackoff
+	for {
+		conn, err := net.DialTimeout(w.network, w.addr, syslogDialTimeout)
+
+		w.mutex.Lock()
+		if err == nil && !w.closed {
+			w.conn = conn
+		} else if err == nil {
+			conn.Close()
+		}
+		if err == nil || w.closed {
+			w.redialing = false
+			w.mutex.Unlock()
+			return
+		}
+		w.mutex.Unlock()
+
+		select {
+		case <-time.After(backoff):
+		case <-w.done:
+			w.mutex.Lock()
+			w.redialing = false
+			w.mutex.Unlock()
+			return
+		}
+		if backoff *= 2; backoff > syslogMaxBackoff {
+			backoff = syslogMaxBackoff
+		}
+	}
+}
+
+// format builds the RFC 5424 message, framed with octet counting on stream sockets
+func (w *Syslog) format(data []byte, level levels.Level) []byte {
+	severity, ok := syslogSeverities[level]
+	if !ok {
+		severity = 6
+	}
+	msg, sd, timestamp := string(data), "-", time.Now()
+	if fields, ok := parseJSONObject(data); ok && !w.flatten {
+		// keep the original event time for events buffered before writing
+		if value, ok := fields["timestamp"].(string); ok {
+			if t, err := time.Parse("2006-01-02T15:04:05-0700", value); err == nil {
+				timestamp = t
+			}
+			delete(fields, "timestamp")
+		}
+		if m, ok := fields["msg"].(string); ok {
+			msg = m
+			delete(fields, "msg")
+		}
+		sd = structuredData(w.sdID, fields)
+	}
+
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "<%d>1 %s %s %s %d - %s %s",
+		w.facility*8+severity, timestamp.Format(time.RFC3339Nano), w.hostname, nilValue(w.tag), os.Getpid(), sd, msg)
+
+	if w.network == "tcp" || w.network == "tcp4" || w.network == "tcp6" || w.network == "unix" {
+		return append([]byte(fmt.Sprintf("%d ", buffer.Len())), buffer.Bytes()...)
+	}
+	return buffer.Bytes()
+}
+
+func parseJSONObject(data []byte) (map[string]interface{}, bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+// structuredData encodes the fields as a single RFC 5424 SD-ELEMENT
+func structuredData(id string, fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	builder.WriteString("[")
+	builder.WriteString(id)
+	for _, k := range keys {
+		builder.WriteString(" ")
+		builder.WriteString(sdName(k))
+		builder.WriteString(`="`)
+		builder.WriteString(sdParamValueReplacer.Replace(fmt.Sprint(fields[k])))
+		builder.WriteString(`"`)
+	}
+	builder.WriteString("]")
+	return builder.String()
+}
+
+var sdParamValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// sdName strips the characters not allowed in a SD-NAME, used for SD-IDs
+// and PARAM-NAMEs
+func sdName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= 32 || r >= 127 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	return name
+}
+
+func nilValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// SupportsColor returns false as syslog messages are plain text
+func (w *Syslog) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the output is a syslog server
+func (w *Syslog) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns true so that metadata becomes structured data
+func (w *Syslog) PrefersJSON() bool {
+	return true
+}
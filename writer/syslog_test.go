@@ -0,0 +1,153 @@
+package writer
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+func TestSyslogUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	w, err := NewSyslog("udp", conn.LocalAddr().String(), FacilityLocal0, "nuclei")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	w.Write([]byte(`{"level":"error","msg":"request failed","host":"example.com","reason":"a \"quoted\" ]"}`), levels.LevelError)
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buffer := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := string(buffer[:n])
+	// local0 (16) * 8 + error (3)
+	if !strings.HasPrefix(message, "<131>1 ") || !strings.Contains(message, " nuclei ") {
+		t.Errorf("unexpected header in %q", message)
+	}
+	if want := ` [meta@32473 host="example.com" level="error" reason="a \"quoted\" \]"] request failed`; !strings.HasSuffix(message, want) {
+		t.Errorf("got %q, want the structured data and message %q", message, want)
+	}
+}
+
+func TestSyslogTCPFraming(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	w, err := NewSyslog("tcp", listener.Addr().String(), FacilityUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	w.Write([]byte("plain warning"), levels.LevelWarning)
+	w.Write([]byte("plain debug"), levels.LevelDebug)
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	for _, want := range []struct{ prefix, suffix string }{
+		{"<12>1 ", " - " + strconv.Itoa(os.Getpid()) + " - - plain warning"},
+		{"<15>1 ", " - " + strconv.Itoa(os.Getpid()) + " - - plain debug"},
+	} {
+		// messages are framed with octet counting
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			t.Fatal(err)
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			t.Fatalf("invalid frame length %q", length)
+		}
+		frame := make([]byte, size)
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			t.Fatal(err)
+		}
+		if message := string(frame); !strings.HasPrefix(message, want.prefix) || !strings.HasSuffix(message, want.suffix) {
+			t.Errorf("got %q, want %q...%q", message, want.prefix, want.suffix)
+		}
+	}
+}
+
+func TestSyslogReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	w, err := NewSyslog("tcp", listener.Addr().String(), FacilityUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	// writes fail once the peer reset the connection, they are dropped
+	// without waiting for the reconnection
+	deadline := time.Now().Add(5 * time.Second)
+	for w.Dropped() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("writes never failed on the closed connection")
+		}
+		w.Write([]byte("lost"), levels.LevelInfo)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	conn, err = listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for connected := false; !connected; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the writer never reconnected")
+		}
+		w.mutex.Lock()
+		connected = w.conn != nil
+		w.mutex.Unlock()
+	}
+
+	w.Write([]byte("after reconnection"), levels.LevelInfo)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	size, err := strconv.Atoi(strings.TrimSpace(length))
+	if err != nil {
+		t.Fatalf("invalid frame length %q", length)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(reader, frame); err != nil {
+		t.Fatal(err)
+	}
+	if message := string(frame); !strings.HasSuffix(message, " - - after reconnection") {
+		t.Errorf("got %q, want the message written after the reconnection", message)
+	}
+}
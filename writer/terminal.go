@@ -0,0 +1,45 @@
+package writer
+
+import (
+	"os"
+	"strconv"
+)
+
+// IsTerminal reports whether the file is attached to a terminal
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// SupportsColor reports whether colored output should be rendered on the file.
+// FORCE_COLOR enables colors even when not on a terminal while NO_COLOR and
+// TERM=dumb disable them. On Windows virtual terminal processing is enabled
+// on the console so that escape sequences are interpreted.
+func SupportsColor(f *os.File) bool {
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok && force != "0" && force != "false" {
+		return true
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(f) && enableVirtualTerminal(f)
+}
+
+// TerminalWidth returns the terminal width from the COLUMNS environment
+// variable or 0 if unknown.
+func TerminalWidth() int {
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width < 0 {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package writer
+
+import "os"
+
+// enableVirtualTerminal is a no-op as terminals interpret escape sequences
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
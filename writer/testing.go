@@ -0,0 +1,63 @@
+package writer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Testing is a concurrent writer forwarding the events to t.Log, so that
+// the logs of the code under test are interleaved with the test output and
+// only shown by go test on failure or in verbose mode.
+type Testing struct {
+	t     testing.TB
+	mutex *sync.Mutex
+	done  bool
+}
+
+var (
+	_ Writer       = &Testing{}
+	_ Capabilities = &Testing{}
+)
+
+// NewTesting returns a writer logging the events with t. The events written
+// once the test has completed are dropped, as t.Log would panic.
+func NewTesting(t testing.TB) *Testing {
+	w := &Testing{t: t, mutex: &sync.Mutex{}}
+	t.Cleanup(func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		w.done = true
+	})
+	return w
+}
+
+// Write logs the data with t.Log
+func (w *Testing) Write(data []byte, level levels.Level) {
+	w.t.Helper()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.done {
+		return
+	}
+	w.t.Log(string(data))
+}
+
+// SupportsColor returns false as the test output is not a terminal
+func (w *Testing) SupportsColor() bool {
+	return false
+}
+
+// IsTerminal returns false as the test output is not a terminal
+func (w *Testing) IsTerminal() bool {
+	return false
+}
+
+// PrefersJSON returns false, test output is meant for humans
+func (w *Testing) PrefersJSON() bool {
+	return false
+}
@@ -0,0 +1,159 @@
+package writer
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	"github.com/projectdiscovery/gologger/levels"
+)
+
+// Unique is a concurrent writer forwarding each distinct line once to the
+// wrapped writer, so that tools piping their findings through the logger
+// guarantee unique output lines (like httpx -dedupe).
+//
+// Lines are remembered by their 128-bit hash in an exact set, or in a bloom
+// filter of bounded size when Capacity is set, in which case a few unique
+// lines may be dropped as false positives.
+type Unique struct {
+	writer  Writer
+	mutex   *sync.Mutex
+	seen    map[[2]uint64]struct{}
+	bloom   *bloomFilter
+	dropped uint64
+}
+
+var (
+	_ Writer       = &Unique{}
+	_ Capabilities = &Unique{}
+)
+
+// UniqueOptions are the options of the unique writer
+type UniqueOptions struct {
+	// Capacity is the expected number of unique lines, bounding the memory
+	// with a bloom filter. All the lines are remembered when 0.
+	Capacity int
+	// FalsePositiveRate is the probability of dropping a unique line once
+	// Capacity lines have been written, 0.001 by default
+	FalsePositiveRate float64
+}
+
+// NewUnique returns a writer forwarding the distinct lines to w
+func NewUnique(w Writer, options UniqueOptions) *Unique {
+	u := &Unique{writer: w, mutex: &sync.Mutex{}}
+	if options.Capacity > 0 {
+		rate := options.FalsePositiveRate
+		if rate <= 0 || rate >= 1 {
+			rate = 0.001
+		}
+		u.bloom = newBloomFilter(options.Capacity, rate)
+	} else {
+		u.seen = make(map[[2]uint64]struct{})
+	}
+	return u
+}
+
+// Write forwards the data if the line was not written before
+func (u *Unique) Write(data []byte, level levels.Level) {
+	hash := hashLine(data)
+
+	u.mutex.Lock()
+	var duplicate bool
+	if u.bloom != nil {
+		duplicate = !u.bloom.add(hash)
+	} else if _, duplicate = u.seen[hash]; !duplicate {
+		u.seen[hash] = struct{}{}
+	}
+	if duplicate {
+		u.dropped++
+	}
+	u.mutex.Unlock()
+
+	if !duplicate {
+		u.writer.Write(data, level)
+	}
+}
+
+// Duplicates returns the number of duplicate lines dropped
+func (u *Unique) Duplicates() uint64 {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.dropped
+}
+
+// Flush flushes the wrapped writer
+func (u *Unique) Flush() error {
+	if flusher, ok := u.writer.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Close closes the wrapped writer
+func (u *Unique) Close() error {
+	return Close(u.writer)
+}
+
+// SupportsColor returns the color support of the wrapped writer
+func (u *Unique) SupportsColor() bool {
+	if c, ok := u.writer.(Capabilities); ok {
+		return c.SupportsColor()
+	}
+	return false
+}
+
+// IsTerminal returns whether the wrapped writer is a terminal
+func (u *Unique) IsTerminal() bool {
+	if c, ok := u.writer.(Capabilities); ok {
+		return c.IsTerminal()
+	}
+	return false
+}
+
+// PrefersJSON returns whether the wrapped writer prefers JSON events
+func (u *Unique) PrefersJSON() bool {
+	if c, ok := u.writer.(Capabilities); ok {
+		return c.PrefersJSON()
+	}
+	return false
+}
+
+// hashLine returns the 128-bit FNV-1a hash of the line as two halves
+func hashLine(data []byte) [2]uint64 {
+	h := fnv.New128a()
+	h.Write(data)
+	sum := h.Sum(nil)
+	return [2]uint64{binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:])}
+}
+
+// bloomFilter is a fixed size bloom filter using double hashing
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// newBloomFilter returns a bloom filter sized for capacity items with the
+// false positive rate
+func newBloomFilter(capacity int, rate float64) *bloomFilter {
+	size := uint64(math.Ceil(-float64(capacity) * math.Log(rate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/float64(capacity)*math.Ln2)))
+	return &bloomFilter{bits: make([]uint64, (size+63)/64), size: size, hashes: hashes}
+}
+
+// add adds the hash to the filter, returning false if it was probably
+// already present
+func (b *bloomFilter) add(hash [2]uint64) bool {
+	added := false
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (hash[0] + i*hash[1]) % b.size
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if b.bits[word]&mask == 0 {
+			b.bits[word] |= mask
+			added = true
+		}
+	}
+	return added
+}
@@ -1,11 +1,57 @@
 package writer
 
 import (
+	"os"
+
 	"github.com/projectdiscovery/gologger/levels"
 )
 
 // Writer type writes data to an output type.
 type Writer interface {
-	// Write writes the data to an output writer.
+	// Write writes the data to an output writer. The data is reused once
+	// Write returns, writers keeping it must copy it.
 	Write(data []byte, level levels.Level)
 }
+
+// Capabilities is optionally implemented by writers to describe their
+// output, allowing the logger to adjust the formatting automatically.
+type Capabilities interface {
+	// SupportsColor reports whether colored output can be rendered
+	SupportsColor() bool
+	// IsTerminal reports whether the output is attached to a terminal
+	IsTerminal() bool
+	// PrefersJSON reports whether structured output is expected
+	PrefersJSON() bool
+}
+
+// Streamer is optionally implemented by writers printing to the standard
+// streams to declare where the events of a level are written
+type Streamer interface {
+	// Stream returns the file the events of the level are written to
+	Stream(level levels.Level) *os.File
+}
+
+// Transient is optionally implemented by writers able to display transient
+// events, such as progress lines, which are erased by the next event
+type Transient interface {
+	// WriteTransient displays the data until the next write
+	WriteTransient(data []byte, level levels.Level)
+}
+
+// Close flushes and closes the writer if it supports it. Both the
+// Close() and Close() error method forms are handled.
+func Close(w Writer) error {
+	var err error
+	if f, ok := w.(interface{ Flush() error }); ok {
+		err = f.Flush()
+	}
+	switch c := w.(type) {
+	case interface{ Close() error }:
+		if closeErr := c.Close(); err == nil {
+			err = closeErr
+		}
+	case interface{ Close() }:
+		c.Close()
+	}
+	return err
+}